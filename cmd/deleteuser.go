@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/logging"
+)
+
+// deleteUser erases everything stored about a user and prints the deletion report as
+// JSON. it exits non-zero when the deletion couldn't be verified, it is safe to run again.
+//
+//	muzzapp delete-user -user <id>
+func deleteUser(cfg *config.AppConfig, args []string) {
+	flags := flag.NewFlagSet("delete-user", flag.ExitOnError)
+	userID := flags.String("user", "", "id of the user to erase")
+	flags.Parse(args)
+
+	if *userID == "" {
+		logging.Fatal("delete-user needs a user id")
+	}
+
+	service, backends := newService(cfg)
+	defer backends.Close()
+
+	report, err := service.DeleteUserData(context.Background(), *userID)
+	if err != nil {
+		logging.Fatal("failed to delete user data", "user_id", *userID, "error", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logging.Fatal("failed to write deletion report", "error", err)
+	}
+	if !report.Verified {
+		slog.Error("user data deletion not verified, run it again", "user_id", *userID, "remaining", report.Remaining)
+		backends.Close()
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/logging"
+	"github.com/endyapina/muzzapp/internal/service"
+)
+
+// exportUser writes every decision a user made or received, for data subject access
+// requests.
+//
+//	muzzapp export-user -user <id> [-format jsonl|csv] [-o <file>]
+func exportUser(cfg *config.AppConfig, args []string) {
+	flags := flag.NewFlagSet("export-user", flag.ExitOnError)
+	userID := flags.String("user", "", "id of the user to export")
+	format := flags.String("format", string(service.ExportJSONLines), "jsonl or csv")
+	output := flags.String("o", "", "file to write the export to, stdout by default")
+	flags.Parse(args)
+
+	if *userID == "" {
+		logging.Fatal("export-user needs a user id")
+	}
+	exportFormat := service.ExportFormat(*format)
+	if exportFormat != service.ExportJSONLines && exportFormat != service.ExportCSV {
+		logging.Fatal("unknown export format", "format", *format)
+	}
+
+	file := os.Stdout
+	if *output != "" {
+		var err error
+		if file, err = os.Create(*output); err != nil {
+			logging.Fatal("failed to create export file", "error", err)
+		}
+		defer file.Close()
+	}
+
+	service, backends := newService(cfg)
+	defer backends.Close()
+
+	w := bufio.NewWriter(file)
+	count, err := service.ExportUserData(context.Background(), *userID, exportFormat, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		logging.Fatal("failed to export user data", "user_id", *userID, "error", err)
+	}
+	slog.Info("user data exported", "user_id", *userID, "decisions", count)
+}
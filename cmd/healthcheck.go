@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/logging"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthCheck queries the grpc.health.v1 service of the local server and exits non-zero
+// unless it is SERVING. it lets docker-compose probe the container without shipping a
+// separate grpc_health_probe binary.
+//
+//	muzzapp healthcheck
+func healthCheck(cfg *config.AppConfig) {
+	conn, err := grpc.NewClient(fmt.Sprintf("localhost:%s", cfg.GRPCPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		logging.Fatal("failed to create health client", "error", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		logging.Fatal("health check failed", "error", err)
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		slog.Error("service is not serving", "status", resp.Status.String())
+		os.Exit(1)
+	}
+}
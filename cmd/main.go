@@ -1,54 +1,272 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/endyapina/muzzapp/internal/config"
 	"github.com/endyapina/muzzapp/internal/database"
 	"github.com/endyapina/muzzapp/internal/handler"
+	"github.com/endyapina/muzzapp/internal/health"
+	"github.com/endyapina/muzzapp/internal/logging"
+	"github.com/endyapina/muzzapp/internal/metrics"
 	"github.com/endyapina/muzzapp/internal/redis"
 	"github.com/endyapina/muzzapp/internal/repository"
 	"github.com/endyapina/muzzapp/internal/service"
+	"github.com/endyapina/muzzapp/internal/tracing"
 
 	pb "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
 	cfg := config.Load()
 
+	logger, err := logging.New(os.Stderr, cfg)
+	if err != nil {
+		logging.Fatal("failed to set up logging", "error", err)
+	}
+	// components derive their loggers from the default one, so it's set before anything
+	// else is created.
+	slog.SetDefault(logger)
+
+	// without arguments the binary runs the gRPC server, otherwise the first
+	// argument selects a one-off maintenance command.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			migrate(cfg, os.Args[2:])
+		case "warm-cache":
+			warmCache(cfg, os.Args[2:])
+		case "delete-user":
+			deleteUser(cfg, os.Args[2:])
+		case "export-user":
+			exportUser(cfg, os.Args[2:])
+		case "healthcheck":
+			healthCheck(cfg)
+		default:
+			logging.Fatal("unknown command", "command", os.Args[1])
+		}
+		return
+	}
+
+	serve(cfg)
+}
+
+// backends are the connections opened by newService.
+type backends struct {
+	db    *sql.DB
+	cache *redis.Cache
+}
+
+// Close closes the mysql and redis connection pools.
+func (b *backends) Close() {
+	if err := b.db.Close(); err != nil {
+		slog.Error("failed to close database", "error", err)
+	}
+	if err := b.cache.Close(); err != nil {
+		slog.Error("failed to close redis cache", "error", err)
+	}
+}
+
+// newService wires the database, the redis cache and the explore service together.
+func newService(cfg *config.AppConfig) (*service.ExploreService, *backends) {
 	db, err := database.Init(cfg)
 	if err != nil {
-		log.Fatal(fmt.Errorf("failed to connect to DB: %w", err))
+		logging.Fatal("failed to connect to DB", "error", err)
 	}
-	log.Println("database connection successful...")
+	sqlDB, err := db.DB()
+	if err != nil {
+		logging.Fatal("failed to get DB connection pool", "error", err)
+	}
+	slog.Info("database connection successful")
 
 	cache, err := redis.NewCache(cfg)
 	if err != nil {
-		log.Fatal(fmt.Errorf("failed to create redis cache: %w", err))
+		logging.Fatal("failed to create redis cache", "error", err)
 	}
-	log.Println("redis cache connection successful...")
+	slog.Info("redis cache connection successful")
 
 	repo, err := repository.New(db, cfg)
 	if err != nil {
-		log.Fatal(fmt.Errorf("failed to create database repository: %w", err))
+		logging.Fatal("failed to create database repository", "error", err)
+	}
+	svc, err := service.New(repo, cache, cfg)
+	if err != nil {
+		logging.Fatal("failed to create explore service", "error", err)
+	}
+	return svc, &backends{db: sqlDB, cache: cache}
+}
+
+// serve runs the gRPC server until SIGTERM or SIGINT.
+//
+// on shutdown the health status flips to NOT_SERVING first, so load balancers stop
+// routing new requests, and the WatchLikedYou streams are closed, then in-flight requests
+// get ShutdownTimeout to drain before the remaining ones are cut off. the background
+// workers are stopped last and the mysql and redis pools closed once nothing uses them
+// anymore.
+func serve(cfg *config.AppConfig) {
+	if err := cfg.ValidateServe(); err != nil {
+		logging.Fatal("invalid config", "error", err)
+	}
+	if cfg.PaginationSecret == "" {
+		slog.Warn("PAGINATION_RANDOM_SECRET is on, pagination tokens won't survive a restart or work across replicas")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	shutdownTracing, err := tracing.Init(ctx, cfg)
+	if err != nil {
+		logging.Fatal("failed to set up tracing", "error", err)
 	}
-	service := service.New(repo, cache)
-	handler := handler.New(service)
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("failed to flush traces", "error", err)
+		}
+	}()
+
+	service, backends := newService(cfg)
+	defer backends.Close()
+
+	exploreHandler, err := handler.New(service, cfg)
+	if err != nil {
+		logging.Fatal("failed to create handler", "error", err)
+	}
+
+	// background workers outlive the signal until the server has drained, so decisions
+	// recorded by in-flight requests are still relayed to redis.
+	workCtx, stopWork := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	if cfg.WarmCacheOnStartup {
+		// warming runs in the background so a large decisions table doesn't delay
+		// the server from accepting traffic.
+		workers.Go(func() {
+			stats, err := service.WarmCache(workCtx)
+			if err != nil {
+				slog.Error("failed to warm redis cache", "error", err)
+				return
+			}
+			slog.Info("redis cache warmed", "likes", stats.Likes, "recipients", stats.Recipients)
+		})
+	}
+
+	workers.Go(func() { service.RunOutboxRelay(workCtx) })
+	workers.Go(func() { service.RunPassSweeper(workCtx) })
+	workers.Go(func() { service.RunRepopulator(workCtx) })
+	workers.Go(func() { backends.cache.RunSizeSampler(workCtx) })
+
+	metricsServer := &http.Server{
+		Addr:              fmt.Sprintf(":%s", cfg.MetricsPort),
+		Handler:           metricsHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		slog.Info("metrics server running", "port", cfg.MetricsPort)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics server failed", "error", err)
+		}
+	}()
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", cfg.GRPCPort))
 	if err != nil {
-		log.Fatal(err)
+		logging.Fatal("failed to listen", "port", cfg.GRPCPort, "error", err)
+	}
+
+	// the logging interceptor runs first so every line of a call carries its request id,
+	// and both it and the metrics interceptor see the status code the error interceptor
+	// hands to the client. streams are chained the same way.
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			logging.UnaryServerInterceptor,
+			metrics.UnaryServerInterceptor,
+			handler.UnaryErrorInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			logging.StreamServerInterceptor,
+			metrics.StreamServerInterceptor,
+			handler.StreamErrorInterceptor,
+		),
+	)
+	pb.RegisterExploreServiceServer(grpcServer, exploreHandler)
+
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	checker := health.NewChecker(healthServer, map[string]health.Probe{
+		"mysql": backends.db.PingContext,
+		"redis": backends.cache.Ping,
+	}, cfg.HealthCheckInterval, cfg.HealthCheckTimeout, pb.ExploreService_ServiceDesc.ServiceName)
+	workers.Go(func() { checker.Run(workCtx) })
+
+	if cfg.GRPCReflection {
+		reflection.Register(grpcServer)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- grpcServer.Serve(lis) }()
+	slog.Info("gRPC server running", "port", cfg.GRPCPort)
+
+	select {
+	case err := <-serveErr:
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			slog.Error("gRPC server failed", "error", err)
+		}
+	case <-ctx.Done():
+		slog.Info("shutting down")
+	}
+
+	healthServer.Shutdown()
+	service.CloseWatches()
+	drain(grpcServer, cfg.ShutdownTimeout)
+
+	stopWork()
+	workers.Wait()
+
+	// the metrics server stays up until the end so the drain itself can be observed
+	if err := metricsServer.Close(); err != nil {
+		slog.Error("failed to close metrics server", "error", err)
 	}
+	slog.Info("shutdown complete")
+}
+
+// metricsHandler serves the prometheus metrics on /metrics.
+func metricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return mux
+}
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterExploreServiceServer(grpcServer, handler)
+// drain stops the server gracefully, forcing it closed once timeout has passed.
+func drain(server *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
 
-	log.Printf("gRPC Server running on :%s", cfg.GRPCPort)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatal(err)
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		slog.Warn("requests still in flight, closing connections", "timeout", timeout)
+		server.Stop()
+		<-done
 	}
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/database"
+	"github.com/endyapina/muzzapp/internal/logging"
+)
+
+// migrate applies, reverts or inspects the schema migrations.
+//
+//	muzzapp migrate [up]             apply every pending migration
+//	muzzapp migrate down [n]         revert the last n migrations (default 1)
+//	muzzapp migrate status           list the migrations and whether they are applied
+//	muzzapp migrate force <version>  mark migrations up to version as applied, e.g. after
+//	                                 repairing a dirty schema by hand
+func migrate(cfg *config.AppConfig, args []string) {
+	db, err := database.Open(cfg)
+	if err != nil {
+		logging.Fatal("failed to connect to DB", "error", err)
+	}
+	migrator, err := database.NewMigrator(db, database.Migrations)
+	if err != nil {
+		logging.Fatal("failed to load migrations", "error", err)
+	}
+	ctx := context.Background()
+
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch command {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			logging.Fatal("failed to apply migrations", "error", err)
+		}
+		slog.Info("migrations applied", "count", count)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				logging.Fatal("invalid number of migrations to revert", "steps", args[1])
+			}
+		}
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			logging.Fatal("failed to revert migrations", "error", err)
+		}
+		slog.Info("migrations reverted", "count", count)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			logging.Fatal("failed to read migration status", "error", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			state, appliedAt := "pending", ""
+			if s.Applied {
+				state, appliedAt = "applied", time.Unix(s.AppliedAt, 0).UTC().Format(time.RFC3339)
+			}
+			if s.Dirty {
+				state = "dirty"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, appliedAt)
+		}
+		w.Flush()
+
+	case "force":
+		if len(args) < 2 {
+			logging.Fatal("migrate force needs a version")
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			logging.Fatal("invalid migration version", "version", args[1])
+		}
+		if err := migrator.Force(ctx, version); err != nil {
+			logging.Fatal("failed to force migration version", "error", err)
+		}
+		slog.Info("migration version forced", "version", version)
+
+	default:
+		logging.Fatal("unknown migrate command", "command", command)
+	}
+}
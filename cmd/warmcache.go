@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/logging"
+)
+
+// warmCache rebuilds the redis likes cache from the database.
+//
+//	muzzapp warm-cache                  rebuild every recipient
+//	muzzapp warm-cache -recipient <id>  rebuild a single recipient
+func warmCache(cfg *config.AppConfig, args []string) {
+	flags := flag.NewFlagSet("warm-cache", flag.ExitOnError)
+	recipientID := flags.String("recipient", "", "only rebuild the likes of this recipient")
+	flags.Parse(args)
+
+	service, backends := newService(cfg)
+	defer backends.Close()
+	ctx := context.Background()
+
+	if *recipientID != "" {
+		count, err := service.WarmRecipient(ctx, *recipientID)
+		if err != nil {
+			logging.Fatal("failed to warm likes", "recipient_id", *recipientID, "error", err)
+		}
+		slog.Info("redis cache warmed", "likes", count, "recipient_id", *recipientID)
+		return
+	}
+
+	stats, err := service.WarmCache(ctx)
+	if err != nil {
+		logging.Fatal("failed to warm redis cache", "error", err)
+	}
+	slog.Info("redis cache warmed", "likes", stats.Likes, "recipients", stats.Recipients)
+}
@@ -1,11 +1,20 @@
 package config
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
 
+// LikeTimestampMode values
+const (
+	LikeTimestampFirst = "first"
+	LikeTimestampBump  = "bump"
+)
+
 // AppConfig holds all configuration variables
 type AppConfig struct {
 	// Database
@@ -15,6 +24,10 @@ type AppConfig struct {
 	DBPassword string `envconfig:"DB_PASSWORD" default:"password"`
 	DBName     string `envconfig:"DB_NAME" default:"muzzapp"`
 
+	// pending schema migrations are applied on startup. turn this off to run them as a
+	// separate deploy step with "muzzapp migrate up" instead
+	MigrateOnStartup bool `envconfig:"MIGRATE_ON_STARTUP" default:"true"`
+
 	// Redis
 	RedisHost     string `envconfig:"REDIS_HOST" default:"redis"`
 	RedisPort     string `envconfig:"REDIS_PORT" default:"6379"`
@@ -24,15 +37,133 @@ type AppConfig struct {
 	// gRPC
 	GRPCPort string `envconfig:"GRPC_PORT" default:"50051"`
 
-	// pagination size limit
-	PaginationSize int64 `envconfig:"PAGINATION_SIZE" default:"50"`
+	// server reflection lets tools like grpcurl discover the API; keep it off in production
+	GRPCReflection bool `envconfig:"GRPC_REFLECTION" default:"false"`
+
+	// in-flight requests get this long to finish after SIGTERM before they are cut off
+	ShutdownTimeout time.Duration `envconfig:"SHUTDOWN_TIMEOUT" default:"15s"`
+
+	// mysql and redis are pinged every interval to drive the grpc.health.v1 status
+	HealthCheckInterval time.Duration `envconfig:"HEALTH_CHECK_INTERVAL" default:"5s"`
+	HealthCheckTimeout  time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"2s"`
+
+	// metrics are served on /metrics of this port. the sizes of the sorted sets of the
+	// hot recipients (comma separated user ids) are sampled every interval
+	MetricsPort           string        `envconfig:"METRICS_PORT" default:"9090"`
+	MetricsHotRecipients  []string      `envconfig:"METRICS_HOT_RECIPIENTS" default:""`
+	MetricsSampleInterval time.Duration `envconfig:"METRICS_SAMPLE_INTERVAL" default:"30s"`
+
+	// logging: level is one of debug, info, warn or error, format is json or text
+	LogLevel  string `envconfig:"LOG_LEVEL" default:"info"`
+	LogFormat string `envconfig:"LOG_FORMAT" default:"json"`
+
+	// tracing: "none", "stdout" or "otlp". the otlp endpoint defaults to the standard
+	// OTEL_EXPORTER_OTLP_* environment variables when empty
+	ServiceName        string  `envconfig:"SERVICE_NAME" default:"muzzapp-explore"`
+	TracingExporter    string  `envconfig:"TRACING_EXPORTER" default:"none"`
+	TracingSampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+	OTLPEndpoint       string  `envconfig:"OTLP_ENDPOINT" default:""`
+	OTLPInsecure       bool    `envconfig:"OTLP_INSECURE" default:"true"`
+
+	// user ids must match this pattern, e.g. ^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$ for UUIDs
+	UserIDPattern string `envconfig:"USER_ID_PATTERN" default:"^[A-Za-z0-9_-]{1,64}$"`
+
+	// what a repeated like does to its timestamp: "first" keeps the time of the first like
+	// so likers keep their place in ListLikedYou, "bump" moves them to the end of the list
+	LikeTimestampMode string `envconfig:"LIKE_TIMESTAMP_MODE" default:"first"`
+
+	// outcomes of PutDecision calls with an idempotency key are kept this long for retries
+	IdempotencyKeyTTL time.Duration `envconfig:"IDEMPOTENCY_KEY_TTL" default:"24h"`
+
+	// the largest batch of decisions PutDecisions accepts in one call
+	DecisionBatchMaxSize int `envconfig:"DECISION_BATCH_MAX_SIZE" default:"100"`
+
+	// how long after a decision UndoDecision may still revert it
+	UndoWindow time.Duration `envconfig:"UNDO_WINDOW" default:"5m"`
+
+	// a pass expires this long after it was made: the recipient counts as undecided again
+	// and can be shown to the actor. zero keeps passes forever
+	PassExpiry time.Duration `envconfig:"PASS_EXPIRY" default:"2160h"`
+
+	// expired passes are moved from decisions to expired_passes every interval, this many
+	// per transaction
+	PassSweepInterval  time.Duration `envconfig:"PASS_SWEEP_INTERVAL" default:"1h"`
+	PassSweepBatchSize int           `envconfig:"PASS_SWEEP_BATCH_SIZE" default:"500"`
+
+	// DeleteUserData deletes this many rows per table and transaction, so erasing a busy
+	// user doesn't hold locks on thousands of rows at once
+	DeletionBatchSize int `envconfig:"DELETION_BATCH_SIZE" default:"500"`
+
+	// ExportUserData reads this many decisions per query
+	ExportBatchSize int `envconfig:"EXPORT_BATCH_SIZE" default:"1000"`
+
+	// default page size, and the largest page a client may ask for with page_size
+	PaginationSize    int64 `envconfig:"PAGINATION_SIZE" default:"50"`
+	PaginationMaxSize int64 `envconfig:"PAGINATION_MAX_SIZE" default:"200"`
+
+	// pagination tokens are signed with this secret and rejected once older than the TTL.
+	// all replicas must share the secret, so the server requires it unless the random
+	// secret is turned on: meant for local setups, it signs with a new key on every startup
+	PaginationSecret       string        `envconfig:"PAGINATION_SECRET" default:""`
+	PaginationRandomSecret bool          `envconfig:"PAGINATION_RANDOM_SECRET" default:"false"`
+	PaginationTokenTTL     time.Duration `envconfig:"PAGINATION_TOKEN_TTL" default:"24h"`
+
+	// cache warm-up
+	WarmCacheOnStartup bool `envconfig:"WARM_CACHE_ON_STARTUP" default:"true"`
+	WarmCacheBatchSize int  `envconfig:"WARM_CACHE_BATCH_SIZE" default:"1000"`
+
+	// outbox relay. every decision writes three events, so a batch of 300 relays a full
+	// PutDecisions batch in a single redis round-trip
+	OutboxRelayInterval   time.Duration `envconfig:"OUTBOX_RELAY_INTERVAL" default:"1s"`
+	OutboxRelayMaxBackoff time.Duration `envconfig:"OUTBOX_RELAY_MAX_BACKOFF" default:"30s"`
+	OutboxBatchSize       int           `envconfig:"OUTBOX_BATCH_SIZE" default:"300"`
+	OutboxRetention       time.Duration `envconfig:"OUTBOX_RETENTION" default:"24h"`
 }
 
 // Load reads environment variables into AppConfig
 func Load() *AppConfig {
 	var cfg AppConfig
 	if err := envconfig.Process("", &cfg); err != nil {
-		log.Fatalf("Failed to load config from environment: %v", err)
+		// the logger is configured from AppConfig, so this goes through the default one
+		slog.Error("failed to load config from environment", "error", err)
+		os.Exit(1)
+	}
+	if err := cfg.Validate(); err != nil {
+		slog.Error("invalid config", "error", err)
+		os.Exit(1)
 	}
 	return &cfg
 }
+
+// Validate rejects settings envconfig parses fine but the service can't run with.
+func (c *AppConfig) Validate() error {
+	// batched loops stop on a short batch, which a size of zero never returns
+	batchSizes := []struct {
+		name string
+		size int
+	}{
+		{"WARM_CACHE_BATCH_SIZE", c.WarmCacheBatchSize},
+		{"OUTBOX_BATCH_SIZE", c.OutboxBatchSize},
+		{"DELETION_BATCH_SIZE", c.DeletionBatchSize},
+		{"EXPORT_BATCH_SIZE", c.ExportBatchSize},
+		{"PASS_SWEEP_BATCH_SIZE", c.PassSweepBatchSize},
+	}
+	for _, b := range batchSizes {
+		if b.size <= 0 {
+			return fmt.Errorf("%s must be positive, got %d", b.name, b.size)
+		}
+	}
+	if c.PassExpiry > 0 && c.PassSweepInterval <= 0 {
+		return fmt.Errorf("PASS_SWEEP_INTERVAL must be positive, got %s", c.PassSweepInterval)
+	}
+	return nil
+}
+
+// ValidateServe rejects settings only the server can't run with. the other commands never
+// sign a pagination token, so they don't need a secret.
+func (c *AppConfig) ValidateServe() error {
+	if c.PaginationSecret == "" && !c.PaginationRandomSecret {
+		return fmt.Errorf("PAGINATION_SECRET must be set, or PAGINATION_RANDOM_SECRET turned on for local setups")
+	}
+	return nil
+}
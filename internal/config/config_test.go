@@ -0,0 +1,73 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*AppConfig)
+		wantErr string
+	}{
+		{name: "defaults", modify: func(*AppConfig) {}},
+		{name: "zero warm cache batch", modify: func(c *AppConfig) { c.WarmCacheBatchSize = 0 }, wantErr: "WARM_CACHE_BATCH_SIZE"},
+		{name: "negative warm cache batch", modify: func(c *AppConfig) { c.WarmCacheBatchSize = -1 }, wantErr: "WARM_CACHE_BATCH_SIZE"},
+		{name: "zero outbox batch", modify: func(c *AppConfig) { c.OutboxBatchSize = 0 }, wantErr: "OUTBOX_BATCH_SIZE"},
+		{name: "zero deletion batch", modify: func(c *AppConfig) { c.DeletionBatchSize = 0 }, wantErr: "DELETION_BATCH_SIZE"},
+		{name: "zero export batch", modify: func(c *AppConfig) { c.ExportBatchSize = 0 }, wantErr: "EXPORT_BATCH_SIZE"},
+		{name: "zero pass sweep batch", modify: func(c *AppConfig) { c.PassSweepBatchSize = 0 }, wantErr: "PASS_SWEEP_BATCH_SIZE"},
+		{name: "zero pass sweep interval", modify: func(c *AppConfig) { c.PassSweepInterval = 0 }, wantErr: "PASS_SWEEP_INTERVAL"},
+		{name: "no sweeper without expiry", modify: func(c *AppConfig) { c.PassExpiry, c.PassSweepInterval = 0, 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg AppConfig
+			require.NoError(t, envconfig.Process("", &cfg))
+			tt.modify(&cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAppConfig_ValidateServe(t *testing.T) {
+	tests := []struct {
+		name         string
+		secret       string
+		randomSecret bool
+		wantErr      bool
+	}{
+		{name: "secret", secret: "secret"},
+		{name: "random secret", randomSecret: true},
+		{name: "no secret", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg AppConfig
+			require.NoError(t, envconfig.Process("", &cfg))
+			cfg.PaginationSecret, cfg.PaginationRandomSecret = tt.secret, tt.randomSecret
+
+			// the other commands run without a secret
+			require.NoError(t, cfg.Validate())
+
+			err := cfg.ValidateServe()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "PAGINATION_SECRET")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -1,16 +1,40 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/endyapina/muzzapp/internal/config"
-	"github.com/endyapina/muzzapp/internal/models"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
-// Init initializes the database connection using the provided configuration.
+// Init connects to the database and, when MigrateOnStartup is set, brings its schema up
+// to date.
+func Init(config *config.AppConfig) (*gorm.DB, error) {
+	db, err := Open(config)
+	if err != nil {
+		return nil, err
+	}
+	if !config.MigrateOnStartup {
+		return db, nil
+	}
+
+	migrator, err := NewMigrator(db, Migrations)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to migrate schema: %w", err)
+	}
+	return db, nil
+}
+
+// Open initializes the database connection using the provided configuration.
 //
 // This function uses GORM (an ORM library for Go) to quickly set up
 // a connection to a MySQL database. GORM makes it easy to work with
@@ -20,7 +44,7 @@ import (
 // apps, in high-scale production systems it is advised to use raw SQL queries
 // or a lightweight database library. This can give you finer control over
 // performance, query optimization, and transaction handling.
-func Init(config *config.AppConfig) (*gorm.DB, error) {
+func Open(config *config.AppConfig) (*gorm.DB, error) {
 	if config == nil {
 		return nil, fmt.Errorf("missing database config")
 	}
@@ -28,14 +52,13 @@ func Init(config *config.AppConfig) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
 		config.DBUser, config.DBPassword, config.DBHost, config.DBPort, config.DBName)
 
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
-	if err != nil {
-		return nil, err
-	}
-
-	// automatically create or update the schema for the given models.
-	// in production you may want to manage schema migrations
-	// explicitly using a tool like golang-migrate or Flyway
-	db.AutoMigrate(&models.Decision{})
-	return db, nil
+	// only slow statements and errors are logged, every statement is already traced
+	return gorm.Open(mysql.Open(dsn), &gorm.Config{
+		Logger: logger.NewSlogLogger(slog.Default().With("component", "gorm"), logger.Config{
+			SlowThreshold:             200 * time.Millisecond,
+			LogLevel:                  logger.Warn,
+			IgnoreRecordNotFoundError: true,
+			ParameterizedQueries:      true,
+		}),
+	})
 }
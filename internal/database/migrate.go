@@ -0,0 +1,323 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Migrations are the versioned schema migrations of the service.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
+
+// migrationFile matches migration scripts like "0004_add_listing_indexes.up.sql".
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// lockName is the mysql advisory lock serializing migrations across replicas.
+const lockName = "muzzapp_schema_migrations"
+
+// Migration is a single schema change with the scripts applying and reverting it.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// SchemaMigration records an applied migration. a dirty migration failed halfway through:
+// mysql commits DDL statements implicitly, so it can't be rolled back and has to be
+// repaired by hand before migrating again.
+type SchemaMigration struct {
+	Version   int64 `gorm:"primaryKey;autoIncrement:false"`
+	Name      string
+	Dirty     bool
+	AppliedAt int64
+}
+
+// MigrationStatus is a known migration and whether it has been applied.
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	Dirty     bool
+	AppliedAt int64
+}
+
+// ErrDirty is returned when a previous migration failed halfway through.
+var ErrDirty = errors.New("database schema is dirty")
+
+// Migrator applies and reverts versioned sql migrations and records them in the
+// schema_migrations table.
+//
+// migrations are plain sql files embedded into the binary, so a deploy always carries the
+// schema it expects. for bigger teams a dedicated tool like golang-migrate, atlas or
+// gh-ost (for online changes of large tables) may be a better fit.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	logger     *slog.Logger
+}
+
+// NewMigrator loads the migrations in fsys. every version needs both an up and a down
+// script.
+func NewMigrator(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	paths, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, path := range paths {
+		name := path[strings.LastIndex(path, "/")+1:]
+		match := migrationFile.FindStringSubmatch(name)
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+
+		script, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has scripts with different names", version)
+		}
+		if match[3] == "up" {
+			m.Up = string(script)
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d needs both an up and a down script", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return &Migrator{db: db, migrations: migrations, logger: slog.Default().With("component", "migrate")}, nil
+}
+
+// Up applies every pending migration in version order and returns how many were applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	var count int
+	err := m.locked(ctx, func(db *gorm.DB) error {
+		applied, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := m.run(ctx, db, migration, migration.Up, true); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Down reverts the last steps applied migrations and returns how many were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	var count int
+	err := m.locked(ctx, func(db *gorm.DB) error {
+		applied, err := m.applied(db)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.run(ctx, db, migration, migration.Down, false); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	})
+	return count, err
+}
+
+// Force marks every migration up to version as applied and clean, and every later one as
+// not applied, without running any script. it is meant for repairing a dirty schema by
+// hand.
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	return m.locked(ctx, func(db *gorm.DB) error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("version > ?", version).Delete(&SchemaMigration{}).Error; err != nil {
+				return err
+			}
+			for _, migration := range m.migrations {
+				if migration.Version > version {
+					break
+				}
+				if err := saveMigration(tx, SchemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now().Unix(),
+				}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// Status lists every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	db := m.db.WithContext(ctx)
+	if err := createSchemaMigrations(db); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(db)
+	if err != nil && !errors.Is(err, ErrDirty) {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		record, ok := applied[migration.Version]
+		statuses[i] = MigrationStatus{Migration: migration, Applied: ok, Dirty: record.Dirty, AppliedAt: record.AppliedAt}
+	}
+	return statuses, nil
+}
+
+// run executes a single script and records its outcome. the migration is flagged dirty
+// before the script starts and only cleaned up once every statement succeeded.
+func (m *Migrator) run(ctx context.Context, db *gorm.DB, migration Migration, script string, up bool) error {
+	direction := "down"
+	if up {
+		direction = "up"
+	}
+	m.logger.InfoContext(ctx, "running migration", "version", migration.Version, "name", migration.Name, "direction", direction)
+
+	record := SchemaMigration{Version: migration.Version, Name: migration.Name, Dirty: true, AppliedAt: time.Now().Unix()}
+	if err := saveMigration(db, record); err != nil {
+		return err
+	}
+
+	for _, statement := range splitStatements(script) {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("migration %d_%s %s failed, fix the schema and run migrate force: %w",
+				migration.Version, migration.Name, direction, err)
+		}
+	}
+
+	if !up {
+		return db.Where("version = ?", record.Version).Delete(&SchemaMigration{}).Error
+	}
+	return db.Exec("UPDATE schema_migrations SET dirty = ? WHERE version = ?", false, record.Version).Error
+}
+
+// applied returns the applied migrations by version, failing if one of them is dirty.
+func (m *Migrator) applied(db *gorm.DB) (map[int64]SchemaMigration, error) {
+	var records []SchemaMigration
+	if err := db.Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]SchemaMigration, len(records))
+	var dirty error
+	for _, record := range records {
+		applied[record.Version] = record
+		if record.Dirty {
+			dirty = fmt.Errorf("%w: migration %d_%s failed halfway through", ErrDirty, record.Version, record.Name)
+		}
+	}
+	return applied, dirty
+}
+
+// locked runs fn on a single connection holding the migration lock, so replicas starting
+// at the same time don't apply the same migration twice. advisory locks are mysql
+// specific; other databases (sqlite in tests) run unlocked.
+func (m *Migrator) locked(ctx context.Context, fn func(db *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(db *gorm.DB) error {
+		if db.Dialector.Name() == "mysql" {
+			var acquired int
+			if err := db.Raw("SELECT GET_LOCK(?, ?)", lockName, 60).Scan(&acquired).Error; err != nil {
+				return err
+			}
+			if acquired != 1 {
+				return errors.New("timed out waiting for the migration lock")
+			}
+			defer db.Exec("SELECT RELEASE_LOCK(?)", lockName)
+		}
+
+		if err := createSchemaMigrations(db); err != nil {
+			return err
+		}
+		return fn(db)
+	})
+}
+
+// saveMigration inserts or replaces the record of a migration.
+func saveMigration(db *gorm.DB, record SchemaMigration) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "version"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "dirty", "applied_at"}),
+	}).Create(&record).Error
+}
+
+// createSchemaMigrations creates the table recording the applied migrations. the
+// statement is plain sql that works on both mysql and sqlite.
+func createSchemaMigrations(db *gorm.DB) error {
+	return db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    BIGINT       NOT NULL PRIMARY KEY,
+		name       VARCHAR(255) NOT NULL,
+		dirty      BOOLEAN      NOT NULL DEFAULT FALSE,
+		applied_at BIGINT       NOT NULL
+	)`).Error
+}
+
+// splitStatements splits a script into its statements. statements end with a semicolon
+// at the end of a line, and "--" comments are dropped.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSuffix(strings.TrimSpace(current.String()), ";"))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "muzzapp.db")
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s", path)), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+	return db
+}
+
+func TestMigrations(t *testing.T) {
+	migrator, err := NewMigrator(nil, Migrations)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrator.migrations)
+
+	// versions are consecutive, so a missing or duplicated file is caught here
+	for i, m := range migrator.migrations {
+		assert.Equal(t, int64(i+1), m.Version, m.Name)
+		assert.NotEmpty(t, splitStatements(m.Up), m.Name)
+		assert.NotEmpty(t, splitStatements(m.Down), m.Name)
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	fsys := fstest.MapFS{
+		"migrations/0001_create_widgets.up.sql":   {Data: []byte("CREATE TABLE widgets (id INTEGER PRIMARY KEY);\n")},
+		"migrations/0001_create_widgets.down.sql": {Data: []byte("DROP TABLE widgets;\n")},
+		"migrations/0002_add_name.up.sql": {Data: []byte(
+			"-- names are optional\nALTER TABLE widgets ADD COLUMN name TEXT;\nCREATE INDEX idx_widgets_name ON widgets (name);\n")},
+		"migrations/0002_add_name.down.sql": {Data: []byte("DROP INDEX idx_widgets_name;\nALTER TABLE widgets DROP COLUMN name;\n")},
+	}
+	migrator, err := NewMigrator(db, fsys)
+	require.NoError(t, err)
+
+	count, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	require.NoError(t, db.Exec("INSERT INTO widgets (id, name) VALUES (1, 'a')").Error)
+
+	count, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count, "applied migrations run once")
+
+	count, err = migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.False(t, db.Migrator().HasColumn("widgets", "name"))
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestMigrator_Dirty(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	fsys := fstest.MapFS{
+		"migrations/0001_create_widgets.up.sql":   {Data: []byte("CREATE TABLE widgets (id INTEGER PRIMARY KEY);\nCREATE TABLE widgets (id INTEGER);\n")},
+		"migrations/0001_create_widgets.down.sql": {Data: []byte("DROP TABLE widgets;\n")},
+	}
+	migrator, err := NewMigrator(db, fsys)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.Error(t, err)
+
+	// the first statement went through, so the migration can't be retried blindly
+	_, err = migrator.Up(ctx)
+	assert.ErrorIs(t, err, ErrDirty)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Dirty)
+
+	require.NoError(t, migrator.Force(ctx, 1))
+	count, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestNewMigrator_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "missing down script",
+			fsys: fstest.MapFS{"migrations/0001_a.up.sql": {Data: []byte("SELECT 1;")}},
+		},
+		{
+			name: "bad file name",
+			fsys: fstest.MapFS{"migrations/first.sql": {Data: []byte("SELECT 1;")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMigrator(nil, tt.fsys)
+			assert.Error(t, err)
+		})
+	}
+}
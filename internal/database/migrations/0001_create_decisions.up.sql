@@ -0,0 +1,8 @@
+-- IF NOT EXISTS adopts the table of databases created by gorm's AutoMigrate
+CREATE TABLE IF NOT EXISTS decisions (
+    actor_user_id     VARCHAR(191) NOT NULL,
+    recipient_user_id VARCHAR(191) NOT NULL,
+    liked             BOOLEAN      NOT NULL DEFAULT FALSE,
+    unix_timestamp    BIGINT       NOT NULL DEFAULT 0,
+    PRIMARY KEY (actor_user_id, recipient_user_id)
+) ENGINE = InnoDB;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id                BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    op                VARCHAR(32)     NOT NULL,
+    recipient_user_id VARCHAR(191)    NOT NULL,
+    actor_user_id     VARCHAR(191)    NOT NULL,
+    unix_timestamp    BIGINT          NOT NULL DEFAULT 0,
+    attempts          INT             NOT NULL DEFAULT 0,
+    last_error        TEXT,
+    created_at        BIGINT          NOT NULL DEFAULT 0,
+    processed_at      BIGINT          NULL,
+    PRIMARY KEY (id),
+    INDEX idx_outbox_events_processed_at (processed_at)
+) ENGINE = InnoDB;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS matches (
+    user_id         VARCHAR(191) NOT NULL,
+    matched_user_id VARCHAR(191) NOT NULL,
+    unix_timestamp  BIGINT       NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, matched_user_id)
+) ENGINE = InnoDB;
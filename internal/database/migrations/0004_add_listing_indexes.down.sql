@@ -0,0 +1,3 @@
+DROP INDEX idx_matches_user_ts ON matches;
+DROP INDEX idx_decisions_liked_recipient_actor ON decisions;
+DROP INDEX idx_decisions_recipient_liked_ts ON decisions;
@@ -0,0 +1,15 @@
+-- GetLikers and GetNewLikers filter on (recipient_user_id, liked) and page through the
+-- rows in (unix_timestamp, actor_user_id) order. with this index a page is a range scan
+-- that stops after page size + 1 rows instead of a filesort of every like.
+CREATE INDEX idx_decisions_recipient_liked_ts
+    ON decisions (recipient_user_id, liked, unix_timestamp, actor_user_id);
+
+-- ScanLikes streams the likes of every recipient in (recipient_user_id, actor_user_id)
+-- order when the cache is rebuilt.
+CREATE INDEX idx_decisions_liked_recipient_actor
+    ON decisions (liked, recipient_user_id, actor_user_id);
+
+-- ListMatches pages through the matches of a user in (unix_timestamp, matched_user_id)
+-- order.
+CREATE INDEX idx_matches_user_ts
+    ON matches (user_id, unix_timestamp, matched_user_id);
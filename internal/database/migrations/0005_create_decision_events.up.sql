@@ -0,0 +1,16 @@
+-- append-only history of every decision, the decisions table keeps the latest one per pair
+CREATE TABLE decision_events (
+    id                BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    actor_user_id     VARCHAR(191)    NOT NULL,
+    recipient_user_id VARCHAR(191)    NOT NULL,
+    liked             BOOLEAN         NOT NULL,
+    source            VARCHAR(32)     NOT NULL,
+    request_id        VARCHAR(128)    NOT NULL DEFAULT '',
+    unix_timestamp    BIGINT          NOT NULL,
+    PRIMARY KEY (id),
+    -- GetDecisionHistory pages through the events of an actor, or of an actor about a single
+    -- recipient, newest first. innodb appends the primary key to every secondary index, so
+    -- both are walked backwards in id order without a filesort
+    INDEX idx_decision_events_actor (actor_user_id),
+    INDEX idx_decision_events_actor_recipient (actor_user_id, recipient_user_id)
+) ENGINE = InnoDB;
@@ -0,0 +1,12 @@
+-- outcomes of PutDecision calls sent with an idempotency key, so retries don't apply twice
+CREATE TABLE idempotency_keys (
+    actor_user_id     VARCHAR(191) NOT NULL,
+    idempotency_key   VARCHAR(128) NOT NULL,
+    recipient_user_id VARCHAR(191) NOT NULL,
+    liked             BOOLEAN      NOT NULL,
+    mutual_likes      BOOLEAN      NOT NULL,
+    unix_timestamp    BIGINT       NOT NULL,
+    PRIMARY KEY (actor_user_id, idempotency_key),
+    -- expired keys are purged by age
+    INDEX idx_idempotency_keys_ts (unix_timestamp)
+) ENGINE = InnoDB;
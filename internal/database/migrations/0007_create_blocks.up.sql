@@ -0,0 +1,10 @@
+-- users blocked by other users, hidden from each other's likes and matches
+CREATE TABLE blocks (
+    blocker_user_id VARCHAR(191) NOT NULL,
+    blocked_user_id VARCHAR(191) NOT NULL,
+    reason          VARCHAR(512) NOT NULL DEFAULT '',
+    unix_timestamp  BIGINT       NOT NULL,
+    PRIMARY KEY (blocker_user_id, blocked_user_id),
+    -- ListBlocked pages through the blocks of a user oldest first
+    INDEX idx_blocks_blocker_ts (blocker_user_id, unix_timestamp, blocked_user_id)
+) ENGINE = InnoDB;
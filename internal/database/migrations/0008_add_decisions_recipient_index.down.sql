@@ -0,0 +1 @@
+DROP INDEX idx_decisions_recipient_actor ON decisions;
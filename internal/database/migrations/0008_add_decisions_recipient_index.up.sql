@@ -0,0 +1,4 @@
+-- ExportUserData scans the decisions a user received in actor_user_id order, passes
+-- included. the primary key already covers the decisions a user made.
+CREATE INDEX idx_decisions_recipient_actor
+    ON decisions (recipient_user_id, actor_user_id);
@@ -0,0 +1,2 @@
+DROP INDEX idx_decisions_liked_ts ON decisions;
+DROP TABLE IF EXISTS expired_passes;
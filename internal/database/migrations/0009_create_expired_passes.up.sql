@@ -0,0 +1,16 @@
+-- passes older than PASS_EXPIRY, moved out of decisions by the pass sweeper
+CREATE TABLE expired_passes (
+    id                BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    actor_user_id     VARCHAR(191)    NOT NULL,
+    recipient_user_id VARCHAR(191)    NOT NULL,
+    unix_timestamp    BIGINT          NOT NULL,
+    archived_at       BIGINT          NOT NULL,
+    PRIMARY KEY (id),
+    -- DeleteUserData erases the passes a user made or received
+    INDEX idx_expired_passes_actor (actor_user_id),
+    INDEX idx_expired_passes_recipient (recipient_user_id)
+) ENGINE = InnoDB;
+
+-- the sweeper reads the oldest passes first and stops at the expiry cutoff
+CREATE INDEX idx_decisions_liked_ts
+    ON decisions (liked, unix_timestamp);
@@ -0,0 +1,6 @@
+DROP INDEX idx_blocks_blocked ON blocks;
+DROP INDEX idx_matches_matched_user ON matches;
+DROP INDEX idx_idempotency_keys_recipient ON idempotency_keys;
+DROP INDEX idx_outbox_events_recipient ON outbox_events;
+DROP INDEX idx_outbox_events_actor ON outbox_events;
+DROP INDEX idx_decision_events_recipient ON decision_events;
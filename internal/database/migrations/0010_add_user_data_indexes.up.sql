@@ -0,0 +1,21 @@
+-- DeleteUserData selects the rows holding a user id in either column of a pair, a batch
+-- at a time. the primary keys and earlier indexes only cover the first column, so without
+-- these every batch scans the whole table while holding its row locks. mysql merges the two
+-- indexes of a table for the OR.
+CREATE INDEX idx_decision_events_recipient
+    ON decision_events (recipient_user_id);
+
+CREATE INDEX idx_outbox_events_actor
+    ON outbox_events (actor_user_id);
+
+CREATE INDEX idx_outbox_events_recipient
+    ON outbox_events (recipient_user_id);
+
+CREATE INDEX idx_idempotency_keys_recipient
+    ON idempotency_keys (recipient_user_id);
+
+CREATE INDEX idx_matches_matched_user
+    ON matches (matched_user_id);
+
+CREATE INDEX idx_blocks_blocked
+    ON blocks (blocked_user_id);
@@ -0,0 +1,7 @@
+-- the outbox relays and the cache rebuilds lock this row to take turns writing to redis
+CREATE TABLE outbox_relay_locks (
+    id INT NOT NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB;
+
+INSERT INTO outbox_relay_locks (id) VALUES (1);
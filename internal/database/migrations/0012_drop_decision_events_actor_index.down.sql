@@ -0,0 +1,2 @@
+CREATE INDEX idx_decision_events_actor
+    ON decision_events (actor_user_id);
@@ -0,0 +1,3 @@
+-- idx_decision_events_actor_recipient starts with actor_user_id, so it already answers the
+-- lookups of an actor's events and the single column index only costs writes
+DROP INDEX idx_decision_events_actor ON decision_events;
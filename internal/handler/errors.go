@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"net"
+
+	"github.com/endyapina/muzzapp/internal/pagination"
+	redis_cache "github.com/endyapina/muzzapp/internal/redis"
+	"github.com/endyapina/muzzapp/internal/repository"
+	"github.com/endyapina/muzzapp/internal/service"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// UnaryErrorInterceptor maps the errors returned by the handlers onto gRPC status codes,
+// so clients can tell a bad request from an outage instead of getting Unknown for both.
+func UnaryErrorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(ctx, info.FullMethod, err)
+	}
+	return resp, nil
+}
+
+// StreamErrorInterceptor is the streaming counterpart of UnaryErrorInterceptor.
+func StreamErrorInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := handler(srv, stream); err != nil {
+		return toStatus(stream.Context(), info.FullMethod, err)
+	}
+	return nil
+}
+
+// toStatus maps domain errors from the service, repository and redis packages onto gRPC
+// status errors:
+//
+//   - InvalidArgument: the request itself is wrong (bad pagination token, self-decision,
+//     self-block, reused idempotency key)
+//   - FailedPrecondition: the request is valid but can't be served in the current state
+//     (nothing to undo, or too late to undo it)
+//   - NotFound: the requested record doesn't exist
+//   - Unavailable: mysql or redis can't be reached, or the server is shutting down; the
+//     client may retry
+//   - Internal: anything else; the details are logged rather than sent to the client
+//
+// errors that already carry a status are passed through.
+func toStatus(ctx context.Context, method string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, pagination.ErrInvalidCursor), errors.Is(err, service.ErrSelfDecision),
+		errors.Is(err, service.ErrSelfBlock), errors.Is(err, repository.ErrIdempotencyKeyReused):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repository.ErrNothingToUndo), errors.Is(err, repository.ErrUndoExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrShuttingDown), errors.Is(err, redis_cache.ErrWatcherLagging):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, "not found")
+	case isUnavailable(err):
+		slog.ErrorContext(ctx, "backend unavailable", "method", method, "error", err)
+		return status.Error(codes.Unavailable, "service temporarily unavailable")
+	default:
+		slog.ErrorContext(ctx, "internal error", "method", method, "error", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+// isUnavailable reports whether err means mysql or redis couldn't be reached.
+func isUnavailable(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, mysql.ErrInvalidConn) ||
+		errors.Is(err, redis.ErrClosed) ||
+		errors.Is(err, redis.ErrPoolTimeout)
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/pagination"
+	"github.com/endyapina/muzzapp/internal/repository"
+	"github.com/endyapina/muzzapp/internal/service"
+	pb "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "existing status", err: status.Error(codes.PermissionDenied, "denied"), wantCode: codes.PermissionDenied},
+		{name: "canceled", err: context.Canceled, wantCode: codes.Canceled},
+		{name: "deadline", err: fmt.Errorf("query: %w", context.DeadlineExceeded), wantCode: codes.DeadlineExceeded},
+		{name: "invalid cursor", err: pagination.ErrExpiredCursor, wantCode: codes.InvalidArgument},
+		{name: "foreign cursor", err: pagination.ErrForeignCursor, wantCode: codes.InvalidArgument},
+		{name: "self decision", err: service.ErrSelfDecision, wantCode: codes.InvalidArgument},
+		{name: "self block", err: service.ErrSelfBlock, wantCode: codes.InvalidArgument},
+		{name: "reused idempotency key", err: repository.ErrIdempotencyKeyReused, wantCode: codes.InvalidArgument},
+		{name: "nothing to undo", err: repository.ErrNothingToUndo, wantCode: codes.FailedPrecondition},
+		{name: "undo expired", err: repository.ErrUndoExpired, wantCode: codes.FailedPrecondition},
+		{name: "shutting down", err: service.ErrShuttingDown, wantCode: codes.Unavailable},
+		{name: "not found", err: gorm.ErrRecordNotFound, wantCode: codes.NotFound},
+		{name: "network", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, wantCode: codes.Unavailable},
+		{name: "unknown", err: errors.New("boom"), wantCode: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := toStatus(context.Background(), "/test", tt.err)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestValidator(t *testing.T) {
+	v, err := newValidator(&config.AppConfig{UserIDPattern: `^[A-Za-z0-9_-]{1,64}$`})
+	assert.NoError(t, err)
+
+	long := string(make([]byte, maxTokenLength+1))
+	key, empty := "retry-1", ""
+	reason := "spam"
+
+	tests := []struct {
+		name    string
+		check   func() error
+		wantErr bool
+	}{
+		{name: "valid decision", check: func() error { return v.decision("user1", "user2") }},
+		{name: "missing actor", check: func() error { return v.decision("", "user2") }, wantErr: true},
+		{name: "malformed recipient", check: func() error { return v.decision("user1", "user 2") }, wantErr: true},
+		{name: "self decision", check: func() error { return v.decision("user1", "user1") }, wantErr: true},
+		{name: "valid block", check: func() error { return v.block("user1", "user2", &reason) }},
+		{name: "self block", check: func() error { return v.block("user1", "user1", nil) }, wantErr: true},
+		{name: "malformed blocked user", check: func() error { return v.block("user1", "user 2", nil) }, wantErr: true},
+		{name: "oversized block reason", check: func() error { return v.block("user1", "user2", &long) }, wantErr: true},
+		{name: "default export format", check: func() error { _, err := v.exportFormat(pb.ExportUserDataRequest_FORMAT_UNSPECIFIED); return err }},
+		{name: "csv export format", check: func() error { _, err := v.exportFormat(pb.ExportUserDataRequest_FORMAT_CSV); return err }},
+		{name: "unknown export format", check: func() error { _, err := v.exportFormat(7); return err }, wantErr: true},
+		{name: "missing token", check: func() error { return v.paginationToken(nil) }},
+		{name: "oversized token", check: func() error { return v.paginationToken(&long) }, wantErr: true},
+		{name: "missing idempotency key", check: func() error { return v.idempotencyKey(nil) }},
+		{name: "idempotency key", check: func() error { return v.idempotencyKey(&key) }},
+		{name: "empty idempotency key", check: func() error { return v.idempotencyKey(&empty) }, wantErr: true},
+		{name: "oversized idempotency key", check: func() error { return v.idempotencyKey(&long) }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check()
+			if tt.wantErr {
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	_, err = newValidator(&config.AppConfig{UserIDPattern: "("})
+	assert.Error(t, err)
+}
@@ -1,36 +1,157 @@
 package handler
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/repository"
 	"github.com/endyapina/muzzapp/internal/service"
 	pb "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
+
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type ExploreHandler struct {
-	service *service.ExploreService
+	service  *service.ExploreService
+	validate *validator
 	pb.UnimplementedExploreServiceServer
 }
 
-func New(service *service.ExploreService) *ExploreHandler {
-	return &ExploreHandler{service: service}
+func New(service *service.ExploreService, config *config.AppConfig) (*ExploreHandler, error) {
+	validate, err := newValidator(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ExploreHandler{service: service, validate: validate}, nil
 }
 
 func (h *ExploreHandler) PutDecision(ctx context.Context, req *pb.PutDecisionRequest) (*pb.PutDecisionResponse, error) {
-	mutual, err := h.service.PutDecision(ctx, req.ActorUserId, req.RecipientUserId, req.LikedRecipient)
+	if err := h.validate.decision(req.ActorUserId, req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := h.validate.idempotencyKey(req.IdempotencyKey); err != nil {
+		return nil, err
+	}
+
+	mutual, err := h.service.PutDecision(ctx, req.ActorUserId, req.RecipientUserId, req.LikedRecipient, req.GetIdempotencyKey())
 	if err != nil {
 		return nil, err
 	}
 	return &pb.PutDecisionResponse{MutualLikes: mutual}, nil
 }
 
+func (h *ExploreHandler) PutDecisions(ctx context.Context, req *pb.PutDecisionsRequest) (*pb.PutDecisionsResponse, error) {
+	if err := h.validate.userID("actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
+	}
+	if err := h.validate.decisionBatch(len(req.Decisions)); err != nil {
+		return nil, err
+	}
+
+	// invalid decisions get an error in their result, the valid ones are recorded
+	results := make([]*pb.PutDecisionsResponse_Result, len(req.Decisions))
+	var decisions []repository.BatchDecision
+	var positions []int
+	for i, d := range req.Decisions {
+		results[i] = &pb.PutDecisionsResponse_Result{}
+		if err := h.validate.decision(req.ActorUserId, d.RecipientUserId); err != nil {
+			results[i].Error = proto.String(status.Convert(err).Message())
+			continue
+		}
+		decisions = append(decisions, repository.BatchDecision{RecipientID: d.RecipientUserId, Liked: d.LikedRecipient})
+		positions = append(positions, i)
+	}
+
+	if len(decisions) > 0 {
+		mutual, err := h.service.PutDecisions(ctx, req.ActorUserId, decisions)
+		if err != nil {
+			return nil, err
+		}
+		for i, m := range mutual {
+			results[positions[i]].MutualLikes = m
+		}
+	}
+	return &pb.PutDecisionsResponse{Results: results}, nil
+}
+
+func (h *ExploreHandler) UndoDecision(ctx context.Context, req *pb.UndoDecisionRequest) (*pb.UndoDecisionResponse, error) {
+	if err := h.validate.userID("actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
+	}
+
+	undo, err := h.service.UndoDecision(ctx, req.ActorUserId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.UndoDecisionResponse{
+		RecipientUserId: undo.Undone.RecipientUserID,
+		LikedRecipient:  undo.Undone.Liked,
+		MutualLikes:     undo.Mutual,
+	}
+	if undo.Restored != nil {
+		resp.RestoredLikedRecipient = proto.Bool(undo.Restored.Liked)
+	}
+	return resp, nil
+}
+
+func (h *ExploreHandler) GetDecision(ctx context.Context, req *pb.GetDecisionRequest) (*pb.GetDecisionResponse, error) {
+	if err := h.validate.decision(req.ActorUserId, req.RecipientUserId); err != nil {
+		return nil, err
+	}
+
+	d, err := h.service.GetDecision(ctx, req.ActorUserId, req.RecipientUserId)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return &pb.GetDecisionResponse{}, nil
+	}
+
+	resp := &pb.GetDecisionResponse{
+		Decided:        true,
+		LikedRecipient: d.Liked,
+		UnixTimestamp:  uint64(d.UnixTimestamp),
+	}
+	if expiresAt, ok := h.service.PassExpiresAt(*d); ok {
+		resp.ExpiresUnixTimestamp = proto.Uint64(uint64(expiresAt.Unix()))
+	}
+	return resp, nil
+}
+
+func (h *ExploreHandler) HasDecided(ctx context.Context, req *pb.GetDecisionRequest) (*pb.HasDecidedResponse, error) {
+	if err := h.validate.decision(req.ActorUserId, req.RecipientUserId); err != nil {
+		return nil, err
+	}
+
+	decided, err := h.service.HasDecided(ctx, req.ActorUserId, req.RecipientUserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.HasDecidedResponse{Decided: decided}, nil
+}
+
 func (h *ExploreHandler) ListLikedYou(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
+	if err := h.validate.userID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := h.validate.paginationToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+	query, err := h.validate.likesQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
 	var token string
 	if req.PaginationToken != nil {
 		token = *req.PaginationToken
 	}
 
-	likers, nextPaginationToken, err := h.service.ListLikedYou(ctx, req.RecipientUserId, token)
+	likers, nextPaginationToken, err := h.service.ListLikedYou(ctx, req.RecipientUserId, token, query)
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +163,10 @@ func (h *ExploreHandler) ListLikedYou(ctx context.Context, req *pb.ListLikedYouR
 }
 
 func (h *ExploreHandler) CountLikedYou(ctx context.Context, req *pb.CountLikedYouRequest) (*pb.CountLikedYouResponse, error) {
+	if err := h.validate.userID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+
 	count, err := h.service.CountLikedYou(ctx, req.RecipientUserId)
 	if err != nil {
 		return nil, err
@@ -49,13 +174,31 @@ func (h *ExploreHandler) CountLikedYou(ctx context.Context, req *pb.CountLikedYo
 	return &pb.CountLikedYouResponse{Count: count}, nil
 }
 
+func (h *ExploreHandler) WatchLikedYou(req *pb.WatchLikedYouRequest, stream pb.ExploreService_WatchLikedYouServer) error {
+	if err := h.validate.userID("recipient_user_id", req.RecipientUserId); err != nil {
+		return err
+	}
+	return h.service.WatchLikedYou(stream.Context(), req.RecipientUserId, stream.Send)
+}
+
 func (h *ExploreHandler) ListNewLikedYou(ctx context.Context, req *pb.ListLikedYouRequest) (*pb.ListLikedYouResponse, error) {
+	if err := h.validate.userID("recipient_user_id", req.RecipientUserId); err != nil {
+		return nil, err
+	}
+	if err := h.validate.paginationToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+	query, err := h.validate.likesQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
 	var token string
 	if req.PaginationToken != nil {
 		token = *req.PaginationToken
 	}
 
-	likers, nextPaginationToken, err := h.service.ListNewLikedYou(ctx, req.RecipientUserId, token)
+	likers, nextPaginationToken, err := h.service.ListNewLikedYou(ctx, req.RecipientUserId, token, query)
 	if err != nil {
 		return nil, err
 	}
@@ -65,3 +208,171 @@ func (h *ExploreHandler) ListNewLikedYou(ctx context.Context, req *pb.ListLikedY
 		NextPaginationToken: &nextPaginationToken,
 	}, nil
 }
+
+func (h *ExploreHandler) ListMatches(ctx context.Context, req *pb.ListMatchesRequest) (*pb.ListMatchesResponse, error) {
+	if err := h.validate.userID("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	if err := h.validate.paginationToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+
+	var token string
+	if req.PaginationToken != nil {
+		token = *req.PaginationToken
+	}
+
+	matches, nextPaginationToken, err := h.service.ListMatches(ctx, req.UserId, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListMatchesResponse{
+		Matches:             matches,
+		NextPaginationToken: &nextPaginationToken,
+	}, nil
+}
+
+func (h *ExploreHandler) CountMatches(ctx context.Context, req *pb.CountMatchesRequest) (*pb.CountMatchesResponse, error) {
+	if err := h.validate.userID("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	count, err := h.service.CountMatches(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CountMatchesResponse{Count: count}, nil
+}
+
+func (h *ExploreHandler) GetDecisionHistory(ctx context.Context, req *pb.GetDecisionHistoryRequest) (*pb.GetDecisionHistoryResponse, error) {
+	if err := h.validate.userID("actor_user_id", req.ActorUserId); err != nil {
+		return nil, err
+	}
+	if req.RecipientUserId != nil {
+		if err := h.validate.userID("recipient_user_id", *req.RecipientUserId); err != nil {
+			return nil, err
+		}
+	}
+	if err := h.validate.paginationToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+
+	var token string
+	if req.PaginationToken != nil {
+		token = *req.PaginationToken
+	}
+
+	events, nextPaginationToken, err := h.service.GetDecisionHistory(ctx, req.ActorUserId, req.GetRecipientUserId(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetDecisionHistoryResponse{
+		Events:              events,
+		NextPaginationToken: &nextPaginationToken,
+	}, nil
+}
+
+func (h *ExploreHandler) BlockUser(ctx context.Context, req *pb.BlockUserRequest) (*pb.BlockUserResponse, error) {
+	if err := h.validate.block(req.UserId, req.BlockedUserId, req.Reason); err != nil {
+		return nil, err
+	}
+
+	if err := h.service.BlockUser(ctx, req.UserId, req.BlockedUserId, req.GetReason()); err != nil {
+		return nil, err
+	}
+	return &pb.BlockUserResponse{}, nil
+}
+
+func (h *ExploreHandler) UnblockUser(ctx context.Context, req *pb.UnblockUserRequest) (*pb.UnblockUserResponse, error) {
+	if err := h.validate.pair("user_id", req.UserId, "blocked_user_id", req.BlockedUserId); err != nil {
+		return nil, err
+	}
+
+	if err := h.service.UnblockUser(ctx, req.UserId, req.BlockedUserId); err != nil {
+		return nil, err
+	}
+	return &pb.UnblockUserResponse{}, nil
+}
+
+func (h *ExploreHandler) ListBlocked(ctx context.Context, req *pb.ListBlockedRequest) (*pb.ListBlockedResponse, error) {
+	if err := h.validate.userID("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	if err := h.validate.paginationToken(req.PaginationToken); err != nil {
+		return nil, err
+	}
+
+	var token string
+	if req.PaginationToken != nil {
+		token = *req.PaginationToken
+	}
+
+	blocked, nextPaginationToken, err := h.service.ListBlocked(ctx, req.UserId, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListBlockedResponse{
+		Blocked:             blocked,
+		NextPaginationToken: &nextPaginationToken,
+	}, nil
+}
+
+func (h *ExploreHandler) DeleteUserData(ctx context.Context, req *pb.DeleteUserDataRequest) (*pb.DeleteUserDataResponse, error) {
+	if err := h.validate.userID("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	report, err := h.service.DeleteUserData(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteUserDataResponse{
+		Decisions:       uint64(report.Decisions),
+		DecisionEvents:  uint64(report.DecisionEvents),
+		Matches:         uint64(report.Matches),
+		Blocks:          uint64(report.Blocks),
+		ExpiredPasses:   uint64(report.ExpiredPasses),
+		IdempotencyKeys: uint64(report.IdempotencyKeys),
+		OutboxEvents:    uint64(report.OutboxEvents),
+		CachedLikes:     uint64(report.CachedLikes),
+		Remaining:       uint64(report.Remaining),
+		CacheCleared:    report.CacheCleared,
+		Verified:        report.Verified,
+	}, nil
+}
+
+func (h *ExploreHandler) ExportUserData(req *pb.ExportUserDataRequest, stream pb.ExploreService_ExportUserDataServer) error {
+	if err := h.validate.userID("user_id", req.UserId); err != nil {
+		return err
+	}
+	format, err := h.validate.exportFormat(req.Format)
+	if err != nil {
+		return err
+	}
+
+	// the export is sent in chunks of exportChunkSize rather than a message per decision
+	w := bufio.NewWriterSize(chunkWriter{stream}, exportChunkSize)
+	if _, err := h.service.ExportUserData(stream.Context(), req.UserId, format, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// exportChunkSize is the size of the chunks of an export stream.
+const exportChunkSize = 32 << 10
+
+// chunkWriter sends every write to an export stream as a chunk.
+type chunkWriter struct {
+	stream pb.ExploreService_ExportUserDataServer
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	// grpc may marshal the message lazily, and p is the buffer of the bufio.Writer
+	if err := w.stream.Send(&pb.ExportUserDataResponse{Data: bytes.Clone(p)}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/pagination"
+	"github.com/endyapina/muzzapp/internal/service"
+	pb "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxIdempotencyKeyLength matches the idempotency_keys column.
+const maxIdempotencyKeyLength = 128
+
+// maxBlockReasonLength matches the blocks column.
+const maxBlockReasonLength = 512
+
+// maxTokenLength bounds pagination tokens before they are decoded. real tokens are a
+// couple of hundred bytes at most.
+const maxTokenLength = 1024
+
+// validator checks request fields before they reach the service, so malformed requests
+// fail fast with InvalidArgument instead of reaching mysql or redis.
+type validator struct {
+	userIDPattern    *regexp.Regexp
+	decisionBatchMax int
+}
+
+func newValidator(config *config.AppConfig) (*validator, error) {
+	userID, err := regexp.Compile(config.UserIDPattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id pattern: %w", err)
+	}
+	return &validator{userIDPattern: userID, decisionBatchMax: config.DecisionBatchMaxSize}, nil
+}
+
+// userID checks that a required user id field is set and matches the configured format.
+func (v *validator) userID(field, id string) error {
+	if id == "" {
+		return status.Errorf(codes.InvalidArgument, "%s is required", field)
+	}
+	if !v.userIDPattern.MatchString(id) {
+		return status.Errorf(codes.InvalidArgument, "%s is not a valid user id", field)
+	}
+	return nil
+}
+
+// decision checks the users of a like/pass.
+func (v *validator) decision(actorID, recipientID string) error {
+	return v.pair("actor_user_id", actorID, "recipient_user_id", recipientID)
+}
+
+// block checks the users of a block and the optional reason reported with it.
+func (v *validator) block(userID, blockedUserID string, reason *string) error {
+	if err := v.pair("user_id", userID, "blocked_user_id", blockedUserID); err != nil {
+		return err
+	}
+	if reason != nil && len(*reason) > maxBlockReasonLength {
+		return status.Errorf(codes.InvalidArgument, "reason is longer than %d bytes", maxBlockReasonLength)
+	}
+	return nil
+}
+
+// pair checks two user id fields that must name different users.
+func (v *validator) pair(field, id, otherField, otherID string) error {
+	if err := v.userID(field, id); err != nil {
+		return err
+	}
+	if err := v.userID(otherField, otherID); err != nil {
+		return err
+	}
+	if id == otherID {
+		return status.Errorf(codes.InvalidArgument, "%s and %s must be different users", field, otherField)
+	}
+	return nil
+}
+
+// idempotencyKey checks an optional idempotency key.
+func (v *validator) idempotencyKey(key *string) error {
+	if key != nil && (*key == "" || len(*key) > maxIdempotencyKeyLength) {
+		return status.Errorf(codes.InvalidArgument, "idempotency_key must be 1 to %d bytes", maxIdempotencyKeyLength)
+	}
+	return nil
+}
+
+// decisionBatch checks the size of a PutDecisions batch. the decisions themselves are
+// checked one by one, so a bad one doesn't reject the rest of the batch.
+func (v *validator) decisionBatch(size int) error {
+	if size == 0 {
+		return status.Error(codes.InvalidArgument, "decisions is required")
+	}
+	if size > v.decisionBatchMax {
+		return status.Errorf(codes.InvalidArgument, "decisions holds more than %d decisions", v.decisionBatchMax)
+	}
+	return nil
+}
+
+// exportFormat turns the format of an export request into a service format.
+func (v *validator) exportFormat(format pb.ExportUserDataRequest_Format) (service.ExportFormat, error) {
+	switch format {
+	case pb.ExportUserDataRequest_FORMAT_UNSPECIFIED, pb.ExportUserDataRequest_FORMAT_JSON_LINES:
+		return service.ExportJSONLines, nil
+	case pb.ExportUserDataRequest_FORMAT_CSV:
+		return service.ExportCSV, nil
+	default:
+		return "", status.Error(codes.InvalidArgument, "format is not a valid format")
+	}
+}
+
+// paginationToken checks an optional pagination token.
+func (v *validator) paginationToken(token *string) error {
+	if token != nil && len(*token) > maxTokenLength {
+		return status.Error(codes.InvalidArgument, "pagination_token is not a valid token")
+	}
+	return nil
+}
+
+// likesQuery checks the order, time window and page size of a likes listing and turns
+// them into a service query. page sizes above the maximum are capped by the service.
+func (v *validator) likesQuery(req *pb.ListLikedYouRequest) (service.LikesQuery, error) {
+	var query service.LikesQuery
+	switch req.Order {
+	case pb.Order_ORDER_UNSPECIFIED:
+	case pb.Order_ORDER_OLDEST_FIRST:
+		query.Direction = pagination.Ascending
+	case pb.Order_ORDER_NEWEST_FIRST:
+		query.Direction = pagination.Descending
+	default:
+		return query, status.Error(codes.InvalidArgument, "order is not a valid order")
+	}
+
+	if req.Since != nil {
+		if *req.Since > math.MaxInt64 {
+			return query, status.Error(codes.InvalidArgument, "since is out of range")
+		}
+		query.Since = int64(*req.Since)
+	}
+	if req.Until != nil {
+		if *req.Until == 0 || *req.Until > math.MaxInt64 {
+			return query, status.Error(codes.InvalidArgument, "until is out of range")
+		}
+		query.Until = int64(*req.Until)
+	}
+	if query.Until > 0 && query.Since > query.Until {
+		return query, status.Error(codes.InvalidArgument, "since must not be after until")
+	}
+
+	if req.PageSize != nil {
+		if *req.PageSize == 0 {
+			return query, status.Error(codes.InvalidArgument, "page_size must be positive")
+		}
+		query.PageSize = int(*req.PageSize)
+	}
+	return query, nil
+}
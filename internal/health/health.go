@@ -0,0 +1,81 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Probe checks that a backend the service depends on can be reached, e.g. a mysql or
+// redis ping.
+type Probe func(ctx context.Context) error
+
+// Checker keeps the status of a grpc.health.v1 server in line with its probes.
+//
+// the service can't answer anything useful without mysql or redis, so it only reports
+// SERVING while every probe passes. load balancers and kubernetes readiness probes then
+// stop routing to a replica that lost a backend instead of letting its requests fail.
+type Checker struct {
+	server   *health.Server
+	services []string
+	probes   map[string]Probe
+	interval time.Duration
+	timeout  time.Duration
+	logger   *slog.Logger
+}
+
+// NewChecker returns a checker updating the overall status ("") and the status of each
+// of services on server.
+func NewChecker(server *health.Server, probes map[string]Probe, interval, timeout time.Duration, services ...string) *Checker {
+	return &Checker{
+		server:   server,
+		services: append([]string{""}, services...),
+		probes:   probes,
+		interval: interval,
+		timeout:  timeout,
+		logger:   slog.Default().With("component", "health"),
+	}
+}
+
+// Run probes the backends every interval until ctx is cancelled.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs every probe once, updates the serving status and reports whether all of
+// them passed.
+func (c *Checker) Check(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	healthy := true
+	for name, probe := range c.probes {
+		if err := probe(ctx); err != nil {
+			c.logger.WarnContext(ctx, "health check failed", "backend", name, "error", err)
+			healthy = false
+		}
+	}
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if !healthy {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+	return healthy
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestChecker_Check(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	down := func(context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name       string
+		probes     map[string]Probe
+		wantStatus healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{
+			name:       "all backends up",
+			probes:     map[string]Probe{"mysql": ok, "redis": ok},
+			wantStatus: healthpb.HealthCheckResponse_SERVING,
+		},
+		{
+			name:       "redis down",
+			probes:     map[string]Probe{"mysql": ok, "redis": down},
+			wantStatus: healthpb.HealthCheckResponse_NOT_SERVING,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := health.NewServer()
+			checker := NewChecker(server, tt.probes, time.Second, time.Second, "explore")
+
+			healthy := checker.Check(ctx)
+			assert.Equal(t, tt.wantStatus == healthpb.HealthCheckResponse_SERVING, healthy)
+
+			for _, service := range []string{"", "explore"} {
+				resp, err := server.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, resp.Status)
+			}
+		})
+	}
+}
+
+func TestChecker_ShutdownWins(t *testing.T) {
+	ctx := context.Background()
+	server := health.NewServer()
+	checker := NewChecker(server, map[string]Probe{}, time.Second, time.Second)
+
+	// once the server is draining, passing probes must not flip it back to SERVING.
+	server.Shutdown()
+	checker.Check(ctx)
+
+	resp, err := server.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+}
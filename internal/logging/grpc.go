@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader is the metadata key the request id is read from and echoed back in.
+const RequestIDHeader = "x-request-id"
+
+// maxRequestIDLength bounds client supplied request ids, longer ones are replaced.
+const maxRequestIDLength = 128
+
+// UnaryServerInterceptor attaches a request id to the context of every call and logs the
+// outcome of the call. the id is taken from the x-request-id metadata so it can follow a
+// request across services, or generated when the client didn't send one, and is returned
+// to the client in the response header.
+//
+// it should be the outermost interceptor so that every line logged while handling the
+// call carries the id.
+func UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	requestID := incomingRequestID(ctx)
+	ctx = WithRequestID(ctx, requestID)
+	// a failure only means the client won't see the id, the call goes ahead regardless
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCompleted(ctx, info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor. the
+// handler gets a stream whose context carries the request id, and the outcome is logged
+// once the stream ends.
+func StreamServerInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	requestID := incomingRequestID(stream.Context())
+	ctx := WithRequestID(stream.Context(), requestID)
+	_ = stream.SetHeader(metadata.Pairs(RequestIDHeader, requestID))
+
+	start := time.Now()
+	err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	logCompleted(ctx, info.FullMethod, start, err)
+	return err
+}
+
+// contextStream is a server stream with a derived context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// logCompleted logs the outcome of a call that started at start.
+func logCompleted(ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+	attrs := []any{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	slog.Log(ctx, levelFor(code), "request completed", attrs...)
+}
+
+// levelFor logs server side failures as errors. client mistakes are part of normal
+// operation and logged like successful calls.
+func levelFor(code codes.Code) slog.Level {
+	switch code {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable, codes.DeadlineExceeded:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDHeader); len(ids) > 0 && ids[0] != "" && len(ids[0]) <= maxRequestIDLength {
+			return ids[0]
+		}
+	}
+	return newRequestID()
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
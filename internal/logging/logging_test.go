@@ -0,0 +1,154 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/endyapina/muzzapp/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   string
+		format  string
+		wantErr bool
+	}{
+		{name: "json", level: "info", format: FormatJSON},
+		{name: "text", level: "debug", format: FormatText},
+		{name: "unknown level", level: "verbose", format: FormatJSON, wantErr: true},
+		{name: "unknown format", level: "info", format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(&bytes.Buffer{}, &config.AppConfig{LogLevel: tt.level, LogFormat: tt.format})
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	long := strings.Repeat("x", maxRequestIDLength+1)
+
+	tests := []struct {
+		name          string
+		incoming      string
+		err           error
+		wantRequestID string
+		wantLevel     string
+	}{
+		{name: "propagates incoming id", incoming: "req-1", wantRequestID: "req-1", wantLevel: "INFO"},
+		{name: "generates missing id", wantLevel: "INFO"},
+		{name: "replaces oversized id", incoming: long, wantLevel: "INFO"},
+		{name: "client error", incoming: "req-2", err: status.Error(codes.InvalidArgument, "bad"), wantRequestID: "req-2", wantLevel: "INFO"},
+		{name: "server error", incoming: "req-3", err: errors.New("boom"), wantRequestID: "req-3", wantLevel: "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := New(&buf, &config.AppConfig{LogLevel: "info", LogFormat: FormatJSON})
+			require.NoError(t, err)
+			setDefault(t, logger)
+
+			ctx := context.Background()
+			if tt.incoming != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(RequestIDHeader, tt.incoming))
+			}
+
+			var seen string
+			handler := func(ctx context.Context, req any) (any, error) {
+				seen = RequestID(ctx)
+				return nil, tt.err
+			}
+			_, gotErr := UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test/Method"}, handler)
+			assert.Equal(t, tt.err, gotErr)
+
+			if tt.wantRequestID != "" {
+				assert.Equal(t, tt.wantRequestID, seen)
+			} else {
+				assert.Len(t, seen, 32)
+			}
+
+			var line map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+			assert.Equal(t, seen, line["request_id"])
+			assert.Equal(t, "/test/Method", line["method"])
+			assert.Equal(t, tt.wantLevel, line["level"])
+		})
+	}
+}
+
+// setDefault installs logger as the default logger for the duration of the test.
+func setDefault(t *testing.T, logger *slog.Logger) {
+	t.Helper()
+
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, &config.AppConfig{LogLevel: "info", LogFormat: FormatJSON})
+	require.NoError(t, err)
+	setDefault(t, logger)
+
+	stream := &fakeStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "req-1"))}
+	var seen string
+	handler := func(srv any, stream grpc.ServerStream) error {
+		seen = RequestID(stream.Context())
+		slog.InfoContext(stream.Context(), "streaming")
+		return status.Error(codes.Unavailable, "shutting down")
+	}
+	gotErr := StreamServerInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/test/Stream"}, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(gotErr))
+	assert.Equal(t, "req-1", seen)
+	assert.Equal(t, []string{"req-1"}, stream.header.Get(RequestIDHeader))
+
+	// the lines logged by the handler carry the id too
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	for _, raw := range lines {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(raw, &line))
+		assert.Equal(t, "req-1", line["request_id"])
+	}
+	var last map[string]any
+	require.NoError(t, json.Unmarshal(lines[1], &last))
+	assert.Equal(t, "/test/Stream", last["method"])
+	assert.Equal(t, "ERROR", last["level"])
+}
+
+// fakeStream is a server stream that only has a context and records its header.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
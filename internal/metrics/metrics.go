@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// sources a liked-you read can be served from
+const (
+	SourceRedis = "redis"
+	SourceMySQL = "mysql"
+)
+
+// LikesReads counts liked-you reads by the backend that served them, so a redis outage
+// or a cold cache shows up as a shift from the "redis" to the "mysql" source.
+var LikesReads = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "muzzapp_likes_reads_total",
+	Help: "Number of liked-you reads, partitioned by method and the backend that served them.",
+}, []string{"method", "source"})
+
+// RPCDuration and RPCRequests are recorded for every unary call by UnaryServerInterceptor.
+var (
+	RPCDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "muzzapp_grpc_request_duration_seconds",
+		Help:    "Latency of gRPC requests, partitioned by method.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	RPCRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "muzzapp_grpc_requests_total",
+		Help: "Number of gRPC requests, partitioned by method and status code.",
+	}, []string{"method", "code"})
+)
+
+// RedisDuration and RedisErrors are recorded for every redis command by RedisHook.
+// pipelines are recorded once as "pipeline".
+var (
+	RedisDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "muzzapp_redis_command_duration_seconds",
+		Help:    "Latency of redis commands, partitioned by command.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"command"})
+
+	RedisErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "muzzapp_redis_command_errors_total",
+		Help: "Number of failed redis commands, partitioned by command.",
+	}, []string{"command"})
+)
+
+// DBDuration and DBErrors are recorded for every gorm statement by RegisterGORMCallbacks.
+var (
+	DBDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "muzzapp_db_query_duration_seconds",
+		Help:    "Latency of database statements, partitioned by operation and table.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	DBErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "muzzapp_db_query_errors_total",
+		Help: "Number of failed database statements, partitioned by operation and table.",
+	}, []string{"operation", "table"})
+)
+
+// LikesSetSize is the size of the sorted sets of the hot recipients, sampled periodically.
+// only the configured recipients are sampled to keep the label cardinality bounded.
+var LikesSetSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "muzzapp_likes_set_size",
+	Help: "Number of members of the liked/new_liked sorted sets of hot recipients.",
+}, []string{"recipient", "set"})
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		err      error
+		wantCode string
+	}{
+		{name: "ok", method: "/test/Ok", wantCode: "OK"},
+		{name: "invalid argument", method: "/test/Invalid", err: status.Error(codes.InvalidArgument, "bad"), wantCode: "InvalidArgument"},
+		{name: "plain error", method: "/test/Plain", err: errors.New("boom"), wantCode: "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &grpc.UnaryServerInfo{FullMethod: tt.method}
+			handler := func(ctx context.Context, req any) (any, error) { return nil, tt.err }
+
+			_, err := UnaryServerInterceptor(context.Background(), nil, info, handler)
+			assert.Equal(t, tt.err, err)
+
+			assert.Equal(t, 1.0, testutil.ToFloat64(RPCRequests.WithLabelValues(tt.method, tt.wantCode)))
+			assert.Equal(t, uint64(1), sampleCount(t, RPCDuration.WithLabelValues(tt.method)))
+		})
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/test/Stream"}
+	handler := func(srv any, stream grpc.ServerStream) error { return status.Error(codes.Canceled, "gone") }
+
+	err := StreamServerInterceptor(nil, nil, info, handler)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(RPCRequests.WithLabelValues("/test/Stream", "Canceled")))
+	assert.Equal(t, uint64(1), sampleCount(t, RPCDuration.WithLabelValues("/test/Stream")))
+}
+
+func TestRegisterGORMCallbacks(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, RegisterGORMCallbacks(db))
+
+	type widget struct {
+		ID   uint
+		Name string
+	}
+	require.NoError(t, db.AutoMigrate(&widget{}))
+
+	require.NoError(t, db.Create(&widget{Name: "a"}).Error)
+	var found widget
+	err = db.Where("name = ?", "missing").Take(&found).Error
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	err = db.Table("no_such_table").Take(&found).Error
+	assert.Error(t, err)
+
+	assert.Equal(t, uint64(1), sampleCount(t, DBDuration.WithLabelValues("create", "widgets")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(DBErrors.WithLabelValues("query", "widgets")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(DBErrors.WithLabelValues("query", "no_such_table")))
+}
+
+// sampleCount returns the number of observations of a histogram.
+func sampleCount(t *testing.T, observer prometheus.Observer) uint64 {
+	t.Helper()
+
+	var m dto.Metric
+	require.NoError(t, observer.(prometheus.Histogram).Write(&m))
+	return m.GetHistogram().GetSampleCount()
+}
@@ -0,0 +1,11 @@
+package models
+
+// Block records that a user blocked another one. a block hides both users from each
+// other's likes and matches, whichever of them created it.
+type Block struct {
+	BlockerUserID string `gorm:"primaryKey"`
+	BlockedUserID string `gorm:"primaryKey"`
+	// Reason is what the blocker reported, for moderation
+	Reason        string
+	UnixTimestamp int64
+}
@@ -0,0 +1,24 @@
+package models
+
+// sources of a decision
+const (
+	DecisionSourceAPI   = "api"
+	DecisionSourceBatch = "batch"
+	// DecisionSourceUndo marks the event of an UndoDecision, which reverted the event
+	// before it; its Liked is the decision in force again
+	DecisionSourceUndo = "undo"
+)
+
+// DecisionEvent is an entry of the append-only decision history. every decision is
+// recorded here in the same transaction that updates the decisions table, which only
+// holds the latest decision of each pair, so a like -> pass -> like flip can still be
+// reconstructed for support.
+type DecisionEvent struct {
+	ID              uint64 `gorm:"primaryKey;autoIncrement"`
+	ActorUserID     string
+	RecipientUserID string
+	Liked           bool
+	Source          string
+	RequestID       string
+	UnixTimestamp   int64
+}
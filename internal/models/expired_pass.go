@@ -0,0 +1,13 @@
+package models
+
+// ExpiredPass is a pass archived by the pass sweeper once it was older than PassExpiry.
+// the pass is gone from the decisions table, so the recipient can be shown to the actor
+// again, but what was decided stays on record.
+type ExpiredPass struct {
+	ID              uint64 `gorm:"primaryKey;autoIncrement"`
+	ActorUserID     string
+	RecipientUserID string
+	// UnixTimestamp is when the pass was made
+	UnixTimestamp int64
+	ArchivedAt    int64
+}
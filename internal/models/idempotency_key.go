@@ -0,0 +1,13 @@
+package models
+
+// IdempotencyKey remembers the outcome of a PutDecision sent with an idempotency key, so a
+// retried call returns it instead of recording the decision again. keys are scoped to the
+// actor and forgotten after IdempotencyKeyTTL.
+type IdempotencyKey struct {
+	ActorUserID     string `gorm:"primaryKey"`
+	Key             string `gorm:"primaryKey;column:idempotency_key"`
+	RecipientUserID string
+	Liked           bool
+	MutualLikes     bool
+	UnixTimestamp   int64
+}
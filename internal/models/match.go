@@ -0,0 +1,9 @@
+package models
+
+// Match records that two users like each other. a match is stored twice, once from the
+// point of view of each user, so listing the matches of a user is a single index scan.
+type Match struct {
+	UserID        string `gorm:"primaryKey"`
+	MatchedUserID string `gorm:"primaryKey"`
+	UnixTimestamp int64
+}
@@ -0,0 +1,32 @@
+package models
+
+// cache operations recorded in the outbox
+const (
+	OutboxAddLike       = "add_like"
+	OutboxRemoveLike    = "remove_like"
+	OutboxAddNewLike    = "add_new_like"
+	OutboxRemoveNewLike = "remove_new_like"
+	// OutboxClearLikes drops both sorted sets of the recipient, the actor is unset
+	OutboxClearLikes = "clear_likes"
+)
+
+// OutboxEvent is a pending redis mutation. it is written in the same database
+// transaction as the decision that caused it and applied to the cache by the outbox
+// relay, so a redis failure can delay the cache but never make it diverge for good.
+type OutboxEvent struct {
+	ID              uint64 `gorm:"primaryKey;autoIncrement"`
+	Op              string
+	RecipientUserID string
+	ActorUserID     string
+	UnixTimestamp   int64
+	Attempts        int
+	LastError       string
+	CreatedAt       int64  `gorm:"autoCreateTime"`
+	ProcessedAt     *int64 `gorm:"index"`
+}
+
+// OutboxRelayLock is the single row the outbox relays and the cache rebuilds lock to take
+// turns writing to redis, so a rebuild can't overwrite a mutation relayed meanwhile.
+type OutboxRelayLock struct {
+	ID int `gorm:"primaryKey"`
+}
@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodec(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	codec, err := NewCodec("secret", time.Hour)
+	require.NoError(t, err)
+	codec.now = func() time.Time { return now }
+
+	cursor := Cursor{Timestamp: 1000, ID: "user1", Direction: Ascending, Owner: "alice"}
+	token := codec.Encode(cursor)
+	other, err := NewCodec("other", time.Hour)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		owner   string
+		elapsed time.Duration
+		want    *Cursor
+		wantErr error
+	}{
+		{
+			name:  "first page",
+			token: "",
+			owner: "alice",
+		},
+		{
+			name:  "round trip",
+			token: token,
+			owner: "alice",
+			want:  &Cursor{Version: version, Timestamp: 1000, ID: "user1", Direction: Ascending, Owner: "alice", IssuedAt: now.Unix()},
+		},
+		{
+			name:    "issued for another user",
+			token:   token,
+			owner:   "bob",
+			wantErr: ErrForeignCursor,
+		},
+		{
+			name:    "expired",
+			token:   token,
+			owner:   "alice",
+			elapsed: 2 * time.Hour,
+			wantErr: ErrExpiredCursor,
+		},
+		{
+			name:    "tampered payload",
+			token:   "x" + token,
+			owner:   "alice",
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "signed with another secret",
+			token:   other.Encode(cursor),
+			owner:   "alice",
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "legacy token",
+			token:   "MTAwMC4wMDAwMDA6dXNlcjE=",
+			owner:   "alice",
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "missing signature",
+			token:   strings.Split(token, ".")[0],
+			owner:   "alice",
+			wantErr: ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec.now = func() time.Time { return now.Add(tt.elapsed) }
+
+			got, err := codec.Decode(tt.token, tt.owner)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidCursor)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewCodec_RandomSecret(t *testing.T) {
+	codec, err := NewCodec("", 0)
+	require.NoError(t, err)
+	other, err := NewCodec("", 0)
+	require.NoError(t, err)
+
+	token := codec.Encode(Cursor{Timestamp: 1000, ID: "user1", Owner: "alice"})
+
+	_, err = codec.Decode(token, "alice")
+	require.NoError(t, err)
+	_, err = other.Decode(token, "alice")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
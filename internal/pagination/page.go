@@ -0,0 +1,54 @@
+package pagination
+
+import "fmt"
+
+// ErrDirectionMismatch is returned when a token is presented with a sort order other than
+// the one of the listing that issued it.
+var ErrDirectionMismatch = fmt.Errorf("%w: token was issued for the other sort order", ErrInvalidCursor)
+
+// Page selects a page of a listing ordered by (timestamp, id): the order it is walked in,
+// an optional time window, how many items it holds and the cursor it starts strictly after.
+// cursors don't depend on the size, so it may change from one page to the next.
+type Page struct {
+	After     *Cursor
+	Direction Direction
+	// Since and Until bound the timestamps inclusively, zero leaves that side open
+	Since int64
+	Until int64
+	// Size of the page, zero leaves it to the backend's default
+	Size int
+}
+
+// Resolve builds the page continuing after cursor. a cursor walks on in the order it was
+// issued for, so direction only has to be set on the first page; an empty direction is
+// oldest first.
+func Resolve(after *Cursor, direction Direction, since, until int64) (Page, error) {
+	if after != nil {
+		if direction != "" && direction != after.Direction {
+			return Page{}, ErrDirectionMismatch
+		}
+		direction = after.Direction
+	}
+	if direction == "" {
+		direction = Ascending
+	}
+	return Page{After: after, Direction: direction, Since: since, Until: until}, nil
+}
+
+// Limit returns the size of the page, or fallback when the page doesn't set one.
+func (p Page) Limit(fallback int64) int {
+	if p.Size > 0 {
+		return p.Size
+	}
+	return int(fallback)
+}
+
+// Descending reports whether the page is walked newest first.
+func (p Page) Descending() bool {
+	return p.Direction == Descending
+}
+
+// Windowed reports whether the page only covers part of the listing's timestamps.
+func (p Page) Windowed() bool {
+	return p.Since > 0 || p.Until > 0
+}
@@ -0,0 +1,39 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	newest := &Cursor{Timestamp: 1000, ID: "user1", Direction: Descending}
+
+	tests := []struct {
+		name      string
+		after     *Cursor
+		direction Direction
+		want      Direction
+		wantErr   error
+	}{
+		{name: "first page defaults to oldest first", want: Ascending},
+		{name: "first page newest first", direction: Descending, want: Descending},
+		{name: "cursor keeps its order", after: newest, want: Descending},
+		{name: "cursor with the same order", after: newest, direction: Descending, want: Descending},
+		{name: "cursor with the other order", after: newest, direction: Ascending, wantErr: ErrDirectionMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := Resolve(tt.after, tt.direction, 10, 20)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidCursor)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, Page{After: tt.after, Direction: tt.want, Since: 10, Until: 20}, page)
+		})
+	}
+}
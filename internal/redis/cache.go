@@ -2,18 +2,24 @@ package redis
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
 
 	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/metrics"
+	"github.com/endyapina/muzzapp/internal/pagination"
 
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
 type Cache struct {
 	client *redis.Client
 	config *config.AppConfig
+	logger *slog.Logger
+	events *eventHub
 }
 
 // NewCache creates and returns a new redis client wrapped inside our Cache struct.
@@ -30,83 +36,214 @@ func NewCache(config *config.AppConfig) (*Cache, error) {
 	if config == nil {
 		return nil, errors.New("missing redis config")
 	}
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", config.RedisHost, config.RedisPort),
+		Password: config.RedisPassword,
+		DB:       config.RedisDB,
+	})
+	client.AddHook(metrics.RedisHook())
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return nil, fmt.Errorf("failed to instrument redis tracing: %w", err)
+	}
+
+	logger := slog.Default().With("component", "redis")
 	return &Cache{
-		client: redis.NewClient(&redis.Options{
-			Addr:     fmt.Sprintf("%s:%s", config.RedisHost, config.RedisPort),
-			Password: config.RedisPassword,
-			DB:       config.RedisDB,
-		}),
+		client: client,
 		config: config,
+		logger: logger,
+		events: newEventHub(client, logger),
 	}, nil
 }
 
-// Add a like to sorted set
-func (c *Cache) AddLike(ctx context.Context, recipientID, actorID string, timestamp int64) error {
-	key := fmt.Sprintf("liked:%s", recipientID)
-	return c.client.ZAdd(ctx, key, redis.Z{
-		Score:  float64(timestamp),
-		Member: actorID,
-	}).Err()
+// Ping checks that redis is reachable.
+func (c *Cache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
 }
 
-// Remove a like from sorted set (used for updates/passes)
-func (c *Cache) RemoveLike(ctx context.Context, recipientID, actorID string) error {
-	key := fmt.Sprintf("liked:%s", recipientID)
-	return c.client.ZRem(ctx, key, actorID).Err()
+// Close closes the pub/sub connection of the like event watchers and the connection pool
+// of the client.
+func (c *Cache) Close() error {
+	return errors.Join(c.events.close(), c.client.Close())
 }
 
-// generateNextToken creates an opaque pagination token
-func generateNextToken(last redis.Z) string {
-	tokenStr := fmt.Sprintf("%f:%s", last.Score, last.Member.(string))
-	return base64.URLEncoding.EncodeToString([]byte(tokenStr))
+// likedKey is the sorted set of everyone who liked the recipient, scored by the
+// time of the like.
+func likedKey(recipientID string) string {
+	return fmt.Sprintf("liked:%s", recipientID)
 }
 
-// parseNextToken decodes a pagination token
-func parseNextToken(token string) (float64, string, error) {
-	if token == "" {
-		return 0, "", nil
-	}
-	data, err := base64.URLEncoding.DecodeString(token)
-	if err != nil {
-		return 0, "", err
+// newLikedKey is the subset of likedKey the recipient hasn't liked back yet. it is kept
+// alongside likedKey so ListNewLikedYou pages are always full without filtering.
+func newLikedKey(recipientID string) string {
+	return fmt.Sprintf("new_liked:%s", recipientID)
+}
+
+// ApplyMutations writes a batch of likes/removals to the sorted sets in a single
+// pipelined round-trip. the commands run in order, so later mutations of the same
+// member win. every mutation is idempotent and the whole batch can safely be retried.
+//
+// in the "first" LikeTimestampMode likes are added with ZADD NX, so a liker already in a set
+// keeps its score and its place in the listing even if a later event carries a newer time.
+func (c *Cache) ApplyMutations(ctx context.Context, mutations []Mutation) error {
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		return c.queueMutations(ctx, pipe, mutations)
+	})
+	return err
+}
+
+// queueMutations adds the commands of mutations to pipe, in order.
+func (c *Cache) queueMutations(ctx context.Context, pipe redis.Pipeliner, mutations []Mutation) error {
+	zadd := pipelinerZAdd
+	if c.config.LikeTimestampMode != config.LikeTimestampBump {
+		zadd = pipelinerZAddNX
 	}
-	var score float64
-	var member string
-	_, err = fmt.Sscanf(string(data), "%f:%s", &score, &member)
-	if err != nil {
-		return 0, "", err
+
+	for _, m := range mutations {
+		switch m.Op {
+		case MutationAddLike:
+			zadd(ctx, pipe, likedKey(m.RecipientID), redis.Z{
+				Score:  float64(m.Timestamp),
+				Member: m.ActorID,
+			})
+		case MutationRemoveLike:
+			pipe.ZRem(ctx, likedKey(m.RecipientID), m.ActorID)
+		case MutationAddNewLike:
+			zadd(ctx, pipe, newLikedKey(m.RecipientID), redis.Z{
+				Score:  float64(m.Timestamp),
+				Member: m.ActorID,
+			})
+		case MutationRemoveNewLike:
+			pipe.ZRem(ctx, newLikedKey(m.RecipientID), m.ActorID)
+		case MutationClearLikes:
+			pipe.Del(ctx, likedKey(m.RecipientID), newLikedKey(m.RecipientID))
+		default:
+			return fmt.Errorf("unknown cache mutation %q", m.Op)
+		}
 	}
-	return score, member, nil
+	return nil
+}
+
+func pipelinerZAdd(ctx context.Context, pipe redis.Pipeliner, key string, z redis.Z) {
+	pipe.ZAdd(ctx, key, z)
 }
 
-// GetLikes fetches likes from Redis with keyset pagination
-func (c *Cache) GetLikers(ctx context.Context, recipientID string, paginationToken string) ([]Z, string, error) {
-	key := fmt.Sprintf("liked:%s", recipientID)
+func pipelinerZAddNX(ctx context.Context, pipe redis.Pipeliner, key string, z redis.Z) {
+	pipe.ZAddNX(ctx, key, z)
+}
 
-	startScore, _, err := parseNextToken(paginationToken)
-	if err != nil {
-		return nil, "", err
+// ReplaceLikes atomically swaps the recipient's "liked" and "new_liked" sorted sets for
+// the given likers, then applies replay on top. The deletes, the re-adds and the replayed
+// mutations run inside MULTI/EXEC so readers never observe a half-built set while the
+// cache is being rehydrated from the database.
+func (c *Cache) ReplaceLikes(ctx context.Context, recipientID string, likers, newLikers []Z, replay []Mutation) error {
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, likedKey(recipientID), newLikedKey(recipientID))
+		if len(likers) > 0 {
+			pipe.ZAdd(ctx, likedKey(recipientID), likers...)
+		}
+		if len(newLikers) > 0 {
+			pipe.ZAdd(ctx, newLikedKey(recipientID), newLikers...)
+		}
+		return c.queueMutations(ctx, pipe, replay)
+	})
+	return err
+}
+
+// GetLikers fetches a page of likes from Redis with keyset pagination, in the page's
+// order and time window, starting strictly after its cursor. it also reports whether there
+// are more likes after the page.
+func (c *Cache) GetLikers(ctx context.Context, recipientID string, page pagination.Page) ([]Z, bool, error) {
+	return c.getPage(ctx, likedKey(recipientID), page)
+}
+
+// GetNewLikers fetches a page of the likes the recipient hasn't reciprocated from Redis
+// with keyset pagination, starting strictly after the page's cursor.
+func (c *Cache) GetNewLikers(ctx context.Context, recipientID string, page pagination.Page) ([]Z, bool, error) {
+	return c.getPage(ctx, newLikedKey(recipientID), page)
+}
+
+// getPage walks a sorted set in (score, member) order, which is how redis orders members
+// sharing a score, or in reverse with ZREVRANGEBYSCORE for newest first pages. the range
+// starts at the cursor's score inclusively and drops the members of that score up to and
+// including the cursor's member, so likes landing in the same second are neither skipped
+// nor repeated across pages.
+func (c *Cache) getPage(ctx context.Context, key string, page pagination.Page) ([]Z, bool, error) {
+	pageSize := page.Limit(c.config.PaginationSize)
+	min, max := scoreRange(page)
+
+	var entries []Z
+	for offset := int64(0); ; {
+		by := &redis.ZRangeBy{Min: min, Max: max, Offset: offset, Count: int64(pageSize + 1)}
+		var zs []Z
+		var err error
+		if page.Descending() {
+			zs, err = c.client.ZRevRangeByScoreWithScores(ctx, key, by).Result()
+		} else {
+			zs, err = c.client.ZRangeByScoreWithScores(ctx, key, by).Result()
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, z := range zs {
+			if seen(page, z) {
+				continue
+			}
+			entries = append(entries, z)
+		}
+
+		// keep going only while whole batches were taken up by the cursor's own score
+		if len(entries) > pageSize || len(zs) <= pageSize {
+			break
+		}
+		offset += int64(len(zs))
 	}
 
-	zs, err := c.client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
-		Min:    fmt.Sprintf("(%f", startScore),
-		Max:    "+inf",
-		Offset: 0,
-		Count:  c.config.PaginationSize,
-	}).Result()
-	if err != nil {
-		return nil, "", err
+	if len(entries) > pageSize {
+		return entries[:pageSize], true, nil
 	}
+	return entries, false, nil
+}
 
-	var nextToken string
-	if len(zs) == int(c.config.PaginationSize) {
-		nextToken = generateNextToken(zs[len(zs)-1])
+// scoreRange returns the score bounds of a page: its time window, narrowed on the side
+// the listing walks towards by the cursor.
+func scoreRange(page pagination.Page) (string, string) {
+	min, max := "-inf", "+inf"
+	since, until := page.Since, page.Until
+	if page.After != nil {
+		if page.Descending() && (until == 0 || page.After.Timestamp < until) {
+			until = page.After.Timestamp
+		}
+		if !page.Descending() && page.After.Timestamp > since {
+			since = page.After.Timestamp
+		}
 	}
+	if since > 0 {
+		min = strconv.FormatInt(since, 10)
+	}
+	if until > 0 {
+		max = strconv.FormatInt(until, 10)
+	}
+	return min, max
+}
 
-	return zs, nextToken, nil
+// seen reports whether a member sharing the cursor's score was already on an earlier page.
+func seen(page pagination.Page, z Z) bool {
+	if page.After == nil || int64(z.Score) != page.After.Timestamp {
+		return false
+	}
+	if page.Descending() {
+		return z.Member.(string) >= page.After.ID
+	}
+	return z.Member.(string) <= page.After.ID
 }
 
 func (c *Cache) CountLikes(ctx context.Context, recipientID string) (int64, error) {
-	key := fmt.Sprintf("liked:%s", recipientID)
-	return c.client.ZCard(ctx, key).Result()
+	return c.client.ZCard(ctx, likedKey(recipientID)).Result()
+}
+
+// HasLikes reports whether either sorted set of the recipient exists.
+func (c *Cache) HasLikes(ctx context.Context, recipientID string) (bool, error) {
+	n, err := c.client.Exists(ctx, likedKey(recipientID), newLikedKey(recipientID)).Result()
+	return n > 0, err
 }
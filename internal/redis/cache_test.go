@@ -0,0 +1,181 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/pagination"
+)
+
+func newTestCache(t *testing.T, cfg *config.AppConfig) (*Cache, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	cfg.RedisHost = server.Host()
+	cfg.RedisPort = server.Port()
+
+	cache, err := NewCache(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { cache.Close() })
+	return cache, server
+}
+
+func TestCache_ApplyMutations_LikeTimestampMode(t *testing.T) {
+	tests := []struct {
+		mode      string
+		wantScore float64
+	}{
+		{mode: config.LikeTimestampFirst, wantScore: 100},
+		{mode: config.LikeTimestampBump, wantScore: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			ctx := context.Background()
+			cache, server := newTestCache(t, &config.AppConfig{LikeTimestampMode: tt.mode})
+
+			require.NoError(t, cache.ApplyMutations(ctx, []Mutation{
+				{Op: MutationAddLike, RecipientID: "bob", ActorID: "alice", Timestamp: 100},
+				{Op: MutationAddNewLike, RecipientID: "bob", ActorID: "alice", Timestamp: 100},
+			}))
+			require.NoError(t, cache.ApplyMutations(ctx, []Mutation{
+				{Op: MutationAddLike, RecipientID: "bob", ActorID: "alice", Timestamp: 200},
+				{Op: MutationAddNewLike, RecipientID: "bob", ActorID: "alice", Timestamp: 200},
+			}))
+
+			for _, key := range []string{likedKey("bob"), newLikedKey("bob")} {
+				score, err := server.ZScore(key, "alice")
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantScore, score, key)
+			}
+
+			// once removed, a like is added again with its new time in both modes
+			require.NoError(t, cache.ApplyMutations(ctx, []Mutation{
+				{Op: MutationRemoveLike, RecipientID: "bob", ActorID: "alice"},
+				{Op: MutationAddLike, RecipientID: "bob", ActorID: "alice", Timestamp: 300},
+			}))
+			score, err := server.ZScore(likedKey("bob"), "alice")
+			require.NoError(t, err)
+			assert.Equal(t, float64(300), score)
+		})
+	}
+}
+
+func TestCache_ApplyMutations_ClearLikes(t *testing.T) {
+	ctx := context.Background()
+	cache, server := newTestCache(t, &config.AppConfig{})
+
+	require.NoError(t, cache.ApplyMutations(ctx, []Mutation{
+		{Op: MutationAddLike, RecipientID: "bob", ActorID: "alice", Timestamp: 100},
+		{Op: MutationAddNewLike, RecipientID: "bob", ActorID: "alice", Timestamp: 100},
+		{Op: MutationAddLike, RecipientID: "carol", ActorID: "alice", Timestamp: 100},
+		{Op: MutationClearLikes, RecipientID: "bob"},
+	}))
+
+	assert.False(t, server.Exists(likedKey("bob")))
+	assert.False(t, server.Exists(newLikedKey("bob")))
+	assert.True(t, server.Exists(likedKey("carol")))
+
+	cached, err := cache.HasLikes(ctx, "bob")
+	require.NoError(t, err)
+	assert.False(t, cached)
+
+	// a new_liked entry alone still counts
+	require.NoError(t, cache.ApplyMutations(ctx, []Mutation{
+		{Op: MutationAddNewLike, RecipientID: "bob", ActorID: "alice", Timestamp: 100},
+	}))
+	cached, err = cache.HasLikes(ctx, "bob")
+	require.NoError(t, err)
+	assert.True(t, cached)
+}
+
+func TestCache_ReplaceLikes(t *testing.T) {
+	ctx := context.Background()
+	cache, server := newTestCache(t, &config.AppConfig{})
+
+	// a stale entry the snapshot no longer holds
+	require.NoError(t, cache.ApplyMutations(ctx, []Mutation{
+		{Op: MutationAddLike, RecipientID: "bob", ActorID: "dave", Timestamp: 50},
+	}))
+
+	// the replayed events land on top of the snapshot, in order
+	require.NoError(t, cache.ReplaceLikes(ctx, "bob",
+		[]Z{{Score: 100, Member: "alice"}, {Score: 200, Member: "carol"}},
+		[]Z{{Score: 100, Member: "alice"}},
+		[]Mutation{
+			{Op: MutationAddLike, RecipientID: "bob", ActorID: "alice", Timestamp: 100},
+			{Op: MutationAddLike, RecipientID: "bob", ActorID: "eve", Timestamp: 300},
+			{Op: MutationAddNewLike, RecipientID: "bob", ActorID: "eve", Timestamp: 300},
+			{Op: MutationRemoveLike, RecipientID: "bob", ActorID: "carol"},
+		}))
+
+	liked, err := server.ZMembers(likedKey("bob"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "eve"}, liked)
+	newLiked, err := server.ZMembers(newLikedKey("bob"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "eve"}, newLiked)
+}
+
+func TestCache_GetLikers(t *testing.T) {
+	likes := map[string]float64{"user1": 1000, "user2": 2000, "user3": 2000, "user4": 2000, "user5": 3000}
+
+	tests := []struct {
+		name string
+		page pagination.Page
+		want []string
+	}{
+		{
+			name: "oldest first",
+			page: pagination.Page{Direction: pagination.Ascending},
+			want: []string{"user1", "user2", "user3", "user4", "user5"},
+		},
+		{
+			name: "newest first",
+			page: pagination.Page{Direction: pagination.Descending},
+			want: []string{"user5", "user4", "user3", "user2", "user1"},
+		},
+		{
+			name: "newest first within a window",
+			page: pagination.Page{Direction: pagination.Descending, Since: 2000, Until: 2000},
+			want: []string{"user4", "user3", "user2"},
+		},
+		{
+			name: "oldest first until a timestamp",
+			page: pagination.Page{Direction: pagination.Ascending, Until: 2000},
+			want: []string{"user1", "user2", "user3", "user4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cache, server := newTestCache(t, &config.AppConfig{PaginationSize: 2})
+			for member, score := range likes {
+				_, err := server.ZAdd(likedKey("alice"), score, member)
+				require.NoError(t, err)
+			}
+
+			var got []string
+			page := tt.page
+			for {
+				zs, more, err := cache.GetLikers(ctx, "alice", page)
+				require.NoError(t, err)
+				for _, z := range zs {
+					got = append(got, z.Member.(string))
+				}
+				if !more {
+					break
+				}
+				last := zs[len(zs)-1]
+				page.After = &pagination.Cursor{Timestamp: int64(last.Score), ID: last.Member.(string), Direction: page.Direction}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// LikeEventType is what happened between the actor and the recipient of a LikeEvent.
+type LikeEventType string
+
+const (
+	LikeEventLiked   LikeEventType = "liked"
+	LikeEventUnliked LikeEventType = "unliked"
+	LikeEventMatched LikeEventType = "matched"
+)
+
+// LikeEvent tells a recipient that the actor liked them, withdrew their like or matched
+// with them.
+type LikeEvent struct {
+	Type        LikeEventType `json:"type"`
+	RecipientID string        `json:"recipient_id"`
+	ActorID     string        `json:"actor_id"`
+	Timestamp   int64         `json:"ts"`
+}
+
+// ErrWatcherLagging ends a watch whose consumer fell too far behind the published events.
+var ErrWatcherLagging = errors.New("like event watcher fell behind")
+
+// watcherBuffer is how many events a watcher may lag behind before it is dropped.
+const watcherBuffer = 64
+
+// likeEventsChannel is the pub/sub channel of the like events of a recipient.
+func likeEventsChannel(recipientID string) string {
+	return "like_events:" + recipientID
+}
+
+// PublishLikeEvents publishes events to the channels of their recipients in a single
+// round-trip. pub/sub delivers at most once to whoever is subscribed right now, so a
+// client that wasn't watching catches up by listing its likes.
+func (c *Cache) PublishLikeEvents(ctx context.Context, events []LikeEvent) error {
+	pipe := c.client.Pipeline()
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		pipe.Publish(ctx, likeEventsChannel(event.RecipientID), payload)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// WatchLikeEvents calls fn with every like event published for the recipient, from any
+// replica, until ctx is done or fn fails. a watcher too slow to keep up is dropped with
+// ErrWatcherLagging rather than silently missing events.
+func (c *Cache) WatchLikeEvents(ctx context.Context, recipientID string, fn func(LikeEvent) error) error {
+	events, unsubscribe, err := c.events.subscribe(ctx, recipientID)
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return ErrWatcherLagging
+			}
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// eventHub shares a single pub/sub connection between every watcher of this replica,
+// instead of holding a redis connection per connected client. it is subscribed to the
+// channels of the recipients being watched and fans their events out to the watchers.
+type eventHub struct {
+	client *redis.Client
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	pubsub   *redis.PubSub
+	watchers map[string]map[chan LikeEvent]struct{}
+}
+
+func newEventHub(client *redis.Client, logger *slog.Logger) *eventHub {
+	return &eventHub{client: client, logger: logger, watchers: map[string]map[chan LikeEvent]struct{}{}}
+}
+
+// subscribe registers a watcher of the recipient's events. the returned function
+// unregisters it and must be called once the watcher is done.
+func (h *eventHub) subscribe(ctx context.Context, recipientID string) (<-chan LikeEvent, func(), error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.pubsub == nil {
+		// the connection is opened by the first subscription and reopened by go-redis,
+		// with every channel resubscribed, whenever it breaks
+		h.pubsub = h.client.Subscribe(context.Background())
+		go h.dispatch(h.pubsub.Channel())
+	}
+
+	watchers, ok := h.watchers[recipientID]
+	if !ok {
+		if err := h.pubsub.Subscribe(ctx, likeEventsChannel(recipientID)); err != nil {
+			return nil, nil, err
+		}
+		watchers = map[chan LikeEvent]struct{}{}
+		h.watchers[recipientID] = watchers
+	}
+
+	events := make(chan LikeEvent, watcherBuffer)
+	watchers[events] = struct{}{}
+	return events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(recipientID, events)
+	}, nil
+}
+
+// remove unregisters a watcher, and unsubscribes from the recipient's channel once nobody
+// watches it anymore. h.mu must be held.
+func (h *eventHub) remove(recipientID string, events chan LikeEvent) {
+	watchers := h.watchers[recipientID]
+	if _, ok := watchers[events]; !ok {
+		return
+	}
+	delete(watchers, events)
+	if len(watchers) > 0 {
+		return
+	}
+
+	delete(h.watchers, recipientID)
+	if err := h.pubsub.Unsubscribe(context.Background(), likeEventsChannel(recipientID)); err != nil {
+		h.logger.Warn("failed to unsubscribe from like events", "recipient_id", recipientID, "error", err)
+	}
+}
+
+// dispatch hands every received event to the watchers of its recipient until the
+// connection is closed.
+func (h *eventHub) dispatch(messages <-chan *redis.Message) {
+	for msg := range messages {
+		var event LikeEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			h.logger.Warn("dropping malformed like event", "channel", msg.Channel, "error", err)
+			continue
+		}
+
+		h.mu.Lock()
+		for events := range h.watchers[event.RecipientID] {
+			select {
+			case events <- event:
+			default:
+				h.logger.Warn("dropping lagging like event watcher", "recipient_id", event.RecipientID)
+				h.remove(event.RecipientID, events)
+				close(events)
+			}
+		}
+		h.mu.Unlock()
+	}
+}
+
+// close closes the shared pub/sub connection, if it was ever opened.
+func (h *eventHub) close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.pubsub == nil {
+		return nil
+	}
+	return h.pubsub.Close()
+}
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/endyapina/muzzapp/internal/config"
+)
+
+func TestCache_WatchLikeEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// events published through one client reach watchers of another, like across replicas
+	publisher, server := newTestCache(t, &config.AppConfig{})
+	watcher, err := NewCache(&config.AppConfig{RedisHost: server.Host(), RedisPort: server.Port()})
+	require.NoError(t, err)
+	t.Cleanup(func() { watcher.Close() })
+
+	received := make(chan LikeEvent)
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.WatchLikeEvents(ctx, "alice", func(event LikeEvent) error {
+			received <- event
+			return nil
+		})
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(server.PubSubChannels("like_events:*")) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	events := []LikeEvent{
+		{Type: LikeEventLiked, RecipientID: "alice", ActorID: "bob", Timestamp: 100},
+		{Type: LikeEventMatched, RecipientID: "bob", ActorID: "alice", Timestamp: 100},
+		{Type: LikeEventMatched, RecipientID: "alice", ActorID: "bob", Timestamp: 100},
+	}
+	require.NoError(t, publisher.PublishLikeEvents(ctx, events))
+
+	// only alice's events are delivered, in order
+	assert.Equal(t, events[0], <-received)
+	assert.Equal(t, events[2], <-received)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	// the channel is left once nobody watches it anymore
+	assert.Eventually(t, func() bool {
+		return len(server.PubSubChannels("like_events:*")) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestEventHub_DropsLaggingWatcher(t *testing.T) {
+	cache, _ := newTestCache(t, &config.AppConfig{})
+	hub := cache.events
+
+	events, unsubscribe, err := hub.subscribe(context.Background(), "alice")
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	messages := make(chan *redis.Message, watcherBuffer+1)
+	for range watcherBuffer + 1 {
+		messages <- &redis.Message{Channel: likeEventsChannel("alice"), Payload: `{"type":"liked","recipient_id":"alice","actor_id":"bob"}`}
+	}
+	close(messages)
+	hub.dispatch(messages)
+
+	var delivered int
+	for range events {
+		delivered++
+	}
+	assert.Equal(t, watcherBuffer, delivered, "the watcher's channel is closed once its buffer overflows")
+}
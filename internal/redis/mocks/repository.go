@@ -5,8 +5,11 @@ package mocks
 import (
 	context "context"
 
+	pagination "github.com/endyapina/muzzapp/internal/pagination"
 	mock "github.com/stretchr/testify/mock"
 
+	redis "github.com/endyapina/muzzapp/internal/redis"
+
 	v9 "github.com/redis/go-redis/v9"
 )
 
@@ -23,17 +26,17 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
-// AddLike provides a mock function with given fields: ctx, recipientID, actorID, timestamp
-func (_m *Repository) AddLike(ctx context.Context, recipientID string, actorID string, timestamp int64) error {
-	ret := _m.Called(ctx, recipientID, actorID, timestamp)
+// ApplyMutations provides a mock function with given fields: ctx, mutations
+func (_m *Repository) ApplyMutations(ctx context.Context, mutations []redis.Mutation) error {
+	ret := _m.Called(ctx, mutations)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AddLike")
+		panic("no return value specified for ApplyMutations")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) error); ok {
-		r0 = rf(ctx, recipientID, actorID, timestamp)
+	if rf, ok := ret.Get(0).(func(context.Context, []redis.Mutation) error); ok {
+		r0 = rf(ctx, mutations)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -41,33 +44,31 @@ func (_m *Repository) AddLike(ctx context.Context, recipientID string, actorID s
 	return r0
 }
 
-// Repository_AddLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLike'
-type Repository_AddLike_Call struct {
+// Repository_ApplyMutations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyMutations'
+type Repository_ApplyMutations_Call struct {
 	*mock.Call
 }
 
-// AddLike is a helper method to define mock.On call
+// ApplyMutations is a helper method to define mock.On call
 //   - ctx context.Context
-//   - recipientID string
-//   - actorID string
-//   - timestamp int64
-func (_e *Repository_Expecter) AddLike(ctx interface{}, recipientID interface{}, actorID interface{}, timestamp interface{}) *Repository_AddLike_Call {
-	return &Repository_AddLike_Call{Call: _e.mock.On("AddLike", ctx, recipientID, actorID, timestamp)}
+//   - mutations []redis.Mutation
+func (_e *Repository_Expecter) ApplyMutations(ctx interface{}, mutations interface{}) *Repository_ApplyMutations_Call {
+	return &Repository_ApplyMutations_Call{Call: _e.mock.On("ApplyMutations", ctx, mutations)}
 }
 
-func (_c *Repository_AddLike_Call) Run(run func(ctx context.Context, recipientID string, actorID string, timestamp int64)) *Repository_AddLike_Call {
+func (_c *Repository_ApplyMutations_Call) Run(run func(ctx context.Context, mutations []redis.Mutation)) *Repository_ApplyMutations_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64))
+		run(args[0].(context.Context), args[1].([]redis.Mutation))
 	})
 	return _c
 }
 
-func (_c *Repository_AddLike_Call) Return(_a0 error) *Repository_AddLike_Call {
+func (_c *Repository_ApplyMutations_Call) Return(_a0 error) *Repository_ApplyMutations_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Repository_AddLike_Call) RunAndReturn(run func(context.Context, string, string, int64) error) *Repository_AddLike_Call {
+func (_c *Repository_ApplyMutations_Call) RunAndReturn(run func(context.Context, []redis.Mutation) error) *Repository_ApplyMutations_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -129,36 +130,36 @@ func (_c *Repository_CountLikes_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
-// GetLikers provides a mock function with given fields: ctx, recipientID, paginationToken
-func (_m *Repository) GetLikers(ctx context.Context, recipientID string, paginationToken string) ([]v9.Z, string, error) {
-	ret := _m.Called(ctx, recipientID, paginationToken)
+// GetLikers provides a mock function with given fields: ctx, recipientID, page
+func (_m *Repository) GetLikers(ctx context.Context, recipientID string, page pagination.Page) ([]v9.Z, bool, error) {
+	ret := _m.Called(ctx, recipientID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLikers")
 	}
 
 	var r0 []v9.Z
-	var r1 string
+	var r1 bool
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]v9.Z, string, error)); ok {
-		return rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) ([]v9.Z, bool, error)); ok {
+		return rf(ctx, recipientID, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []v9.Z); ok {
-		r0 = rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) []v9.Z); ok {
+		r0 = rf(ctx, recipientID, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]v9.Z)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Page) bool); ok {
+		r1 = rf(ctx, recipientID, page)
 	} else {
-		r1 = ret.Get(1).(string)
+		r1 = ret.Get(1).(bool)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(2).(func(context.Context, string, pagination.Page) error); ok {
+		r2 = rf(ctx, recipientID, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -174,39 +175,260 @@ type Repository_GetLikers_Call struct {
 // GetLikers is a helper method to define mock.On call
 //   - ctx context.Context
 //   - recipientID string
-//   - paginationToken string
-func (_e *Repository_Expecter) GetLikers(ctx interface{}, recipientID interface{}, paginationToken interface{}) *Repository_GetLikers_Call {
-	return &Repository_GetLikers_Call{Call: _e.mock.On("GetLikers", ctx, recipientID, paginationToken)}
+//   - page pagination.Page
+func (_e *Repository_Expecter) GetLikers(ctx interface{}, recipientID interface{}, page interface{}) *Repository_GetLikers_Call {
+	return &Repository_GetLikers_Call{Call: _e.mock.On("GetLikers", ctx, recipientID, page)}
 }
 
-func (_c *Repository_GetLikers_Call) Run(run func(ctx context.Context, recipientID string, paginationToken string)) *Repository_GetLikers_Call {
+func (_c *Repository_GetLikers_Call) Run(run func(ctx context.Context, recipientID string, page pagination.Page)) *Repository_GetLikers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(pagination.Page))
 	})
 	return _c
 }
 
-func (_c *Repository_GetLikers_Call) Return(_a0 []v9.Z, _a1 string, _a2 error) *Repository_GetLikers_Call {
+func (_c *Repository_GetLikers_Call) Return(_a0 []v9.Z, _a1 bool, _a2 error) *Repository_GetLikers_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Repository_GetLikers_Call) RunAndReturn(run func(context.Context, string, string) ([]v9.Z, string, error)) *Repository_GetLikers_Call {
+func (_c *Repository_GetLikers_Call) RunAndReturn(run func(context.Context, string, pagination.Page) ([]v9.Z, bool, error)) *Repository_GetLikers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNewLikers provides a mock function with given fields: ctx, recipientID, page
+func (_m *Repository) GetNewLikers(ctx context.Context, recipientID string, page pagination.Page) ([]v9.Z, bool, error) {
+	ret := _m.Called(ctx, recipientID, page)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNewLikers")
+	}
+
+	var r0 []v9.Z
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) ([]v9.Z, bool, error)); ok {
+		return rf(ctx, recipientID, page)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) []v9.Z); ok {
+		r0 = rf(ctx, recipientID, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]v9.Z)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Page) bool); ok {
+		r1 = rf(ctx, recipientID, page)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, pagination.Page) error); ok {
+		r2 = rf(ctx, recipientID, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Repository_GetNewLikers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNewLikers'
+type Repository_GetNewLikers_Call struct {
+	*mock.Call
+}
+
+// GetNewLikers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientID string
+//   - page pagination.Page
+func (_e *Repository_Expecter) GetNewLikers(ctx interface{}, recipientID interface{}, page interface{}) *Repository_GetNewLikers_Call {
+	return &Repository_GetNewLikers_Call{Call: _e.mock.On("GetNewLikers", ctx, recipientID, page)}
+}
+
+func (_c *Repository_GetNewLikers_Call) Run(run func(ctx context.Context, recipientID string, page pagination.Page)) *Repository_GetNewLikers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(pagination.Page))
+	})
+	return _c
+}
+
+func (_c *Repository_GetNewLikers_Call) Return(_a0 []v9.Z, _a1 bool, _a2 error) *Repository_GetNewLikers_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Repository_GetNewLikers_Call) RunAndReturn(run func(context.Context, string, pagination.Page) ([]v9.Z, bool, error)) *Repository_GetNewLikers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HasLikes provides a mock function with given fields: ctx, recipientID
+func (_m *Repository) HasLikes(ctx context.Context, recipientID string) (bool, error) {
+	ret := _m.Called(ctx, recipientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasLikes")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, recipientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, recipientID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, recipientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_HasLikes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasLikes'
+type Repository_HasLikes_Call struct {
+	*mock.Call
+}
+
+// HasLikes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientID string
+func (_e *Repository_Expecter) HasLikes(ctx interface{}, recipientID interface{}) *Repository_HasLikes_Call {
+	return &Repository_HasLikes_Call{Call: _e.mock.On("HasLikes", ctx, recipientID)}
+}
+
+func (_c *Repository_HasLikes_Call) Run(run func(ctx context.Context, recipientID string)) *Repository_HasLikes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_HasLikes_Call) Return(_a0 bool, _a1 error) *Repository_HasLikes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_HasLikes_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *Repository_HasLikes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishLikeEvents provides a mock function with given fields: ctx, events
+func (_m *Repository) PublishLikeEvents(ctx context.Context, events []redis.LikeEvent) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishLikeEvents")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []redis.LikeEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_PublishLikeEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishLikeEvents'
+type Repository_PublishLikeEvents_Call struct {
+	*mock.Call
+}
+
+// PublishLikeEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []redis.LikeEvent
+func (_e *Repository_Expecter) PublishLikeEvents(ctx interface{}, events interface{}) *Repository_PublishLikeEvents_Call {
+	return &Repository_PublishLikeEvents_Call{Call: _e.mock.On("PublishLikeEvents", ctx, events)}
+}
+
+func (_c *Repository_PublishLikeEvents_Call) Run(run func(ctx context.Context, events []redis.LikeEvent)) *Repository_PublishLikeEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]redis.LikeEvent))
+	})
+	return _c
+}
+
+func (_c *Repository_PublishLikeEvents_Call) Return(_a0 error) *Repository_PublishLikeEvents_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_PublishLikeEvents_Call) RunAndReturn(run func(context.Context, []redis.LikeEvent) error) *Repository_PublishLikeEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceLikes provides a mock function with given fields: ctx, recipientID, likers, newLikers, replay
+func (_m *Repository) ReplaceLikes(ctx context.Context, recipientID string, likers []v9.Z, newLikers []v9.Z, replay []redis.Mutation) error {
+	ret := _m.Called(ctx, recipientID, likers, newLikers, replay)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceLikes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []v9.Z, []v9.Z, []redis.Mutation) error); ok {
+		r0 = rf(ctx, recipientID, likers, newLikers, replay)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_ReplaceLikes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceLikes'
+type Repository_ReplaceLikes_Call struct {
+	*mock.Call
+}
+
+// ReplaceLikes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientID string
+//   - likers []v9.Z
+//   - newLikers []v9.Z
+//   - replay []redis.Mutation
+func (_e *Repository_Expecter) ReplaceLikes(ctx interface{}, recipientID interface{}, likers interface{}, newLikers interface{}, replay interface{}) *Repository_ReplaceLikes_Call {
+	return &Repository_ReplaceLikes_Call{Call: _e.mock.On("ReplaceLikes", ctx, recipientID, likers, newLikers, replay)}
+}
+
+func (_c *Repository_ReplaceLikes_Call) Run(run func(ctx context.Context, recipientID string, likers []v9.Z, newLikers []v9.Z, replay []redis.Mutation)) *Repository_ReplaceLikes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]v9.Z), args[3].([]v9.Z), args[4].([]redis.Mutation))
+	})
+	return _c
+}
+
+func (_c *Repository_ReplaceLikes_Call) Return(_a0 error) *Repository_ReplaceLikes_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_ReplaceLikes_Call) RunAndReturn(run func(context.Context, string, []v9.Z, []v9.Z, []redis.Mutation) error) *Repository_ReplaceLikes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveLike provides a mock function with given fields: ctx, recipientID, actorID
-func (_m *Repository) RemoveLike(ctx context.Context, recipientID string, actorID string) error {
-	ret := _m.Called(ctx, recipientID, actorID)
+// WatchLikeEvents provides a mock function with given fields: ctx, recipientID, fn
+func (_m *Repository) WatchLikeEvents(ctx context.Context, recipientID string, fn func(redis.LikeEvent) error) error {
+	ret := _m.Called(ctx, recipientID, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveLike")
+		panic("no return value specified for WatchLikeEvents")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, recipientID, actorID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(redis.LikeEvent) error) error); ok {
+		r0 = rf(ctx, recipientID, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -214,32 +436,32 @@ func (_m *Repository) RemoveLike(ctx context.Context, recipientID string, actorI
 	return r0
 }
 
-// Repository_RemoveLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveLike'
-type Repository_RemoveLike_Call struct {
+// Repository_WatchLikeEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchLikeEvents'
+type Repository_WatchLikeEvents_Call struct {
 	*mock.Call
 }
 
-// RemoveLike is a helper method to define mock.On call
+// WatchLikeEvents is a helper method to define mock.On call
 //   - ctx context.Context
 //   - recipientID string
-//   - actorID string
-func (_e *Repository_Expecter) RemoveLike(ctx interface{}, recipientID interface{}, actorID interface{}) *Repository_RemoveLike_Call {
-	return &Repository_RemoveLike_Call{Call: _e.mock.On("RemoveLike", ctx, recipientID, actorID)}
+//   - fn func(redis.LikeEvent) error
+func (_e *Repository_Expecter) WatchLikeEvents(ctx interface{}, recipientID interface{}, fn interface{}) *Repository_WatchLikeEvents_Call {
+	return &Repository_WatchLikeEvents_Call{Call: _e.mock.On("WatchLikeEvents", ctx, recipientID, fn)}
 }
 
-func (_c *Repository_RemoveLike_Call) Run(run func(ctx context.Context, recipientID string, actorID string)) *Repository_RemoveLike_Call {
+func (_c *Repository_WatchLikeEvents_Call) Run(run func(ctx context.Context, recipientID string, fn func(redis.LikeEvent) error)) *Repository_WatchLikeEvents_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(func(redis.LikeEvent) error))
 	})
 	return _c
 }
 
-func (_c *Repository_RemoveLike_Call) Return(_a0 error) *Repository_RemoveLike_Call {
+func (_c *Repository_WatchLikeEvents_Call) Return(_a0 error) *Repository_WatchLikeEvents_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Repository_RemoveLike_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_RemoveLike_Call {
+func (_c *Repository_WatchLikeEvents_Call) RunAndReturn(run func(context.Context, string, func(redis.LikeEvent) error) error) *Repository_WatchLikeEvents_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,17 +3,42 @@ package redis
 import (
 	"context"
 
+	"github.com/endyapina/muzzapp/internal/pagination"
+
 	goredis "github.com/redis/go-redis/v9"
 )
 
 // Z alias to goredis.Z for cleaner service usage
 type Z = goredis.Z
 
+// MutationOp identifies the kind of write a Mutation performs.
+type MutationOp string
+
+const (
+	MutationAddLike       MutationOp = "add_like"
+	MutationRemoveLike    MutationOp = "remove_like"
+	MutationAddNewLike    MutationOp = "add_new_like"
+	MutationRemoveNewLike MutationOp = "remove_new_like"
+	MutationClearLikes    MutationOp = "clear_likes"
+)
+
+// Mutation is a single write to the likes cache.
+type Mutation struct {
+	Op          MutationOp
+	RecipientID string
+	ActorID     string
+	Timestamp   int64
+}
+
 // Repository is an interface that defines the operations we need from Redis.
 // This allows us to mock the cache implementation when running unit tests.
 type Repository interface {
-	AddLike(ctx context.Context, recipientID, actorID string, timestamp int64) error
-	RemoveLike(ctx context.Context, recipientID, actorID string) error
-	GetLikers(ctx context.Context, recipientID string, paginationToken string) ([]Z, string, error)
+	ApplyMutations(ctx context.Context, mutations []Mutation) error
+	GetLikers(ctx context.Context, recipientID string, page pagination.Page) ([]Z, bool, error)
+	GetNewLikers(ctx context.Context, recipientID string, page pagination.Page) ([]Z, bool, error)
 	CountLikes(ctx context.Context, recipientID string) (int64, error)
+	HasLikes(ctx context.Context, recipientID string) (bool, error)
+	ReplaceLikes(ctx context.Context, recipientID string, likers, newLikers []Z, replay []Mutation) error
+	PublishLikeEvents(ctx context.Context, events []LikeEvent) error
+	WatchLikeEvents(ctx context.Context, recipientID string, fn func(LikeEvent) error) error
 }
@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/endyapina/muzzapp/internal/metrics"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RunSizeSampler records the sizes of the sorted sets of the configured hot recipients
+// every MetricsSampleInterval until ctx is cancelled. it returns right away when no
+// recipients are configured.
+//
+// a handful of very popular recipients dominate memory and ZRANGE costs, so their sets
+// are worth watching; sampling every recipient would explode the label cardinality.
+func (c *Cache) RunSizeSampler(ctx context.Context) {
+	if len(c.config.MetricsHotRecipients) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.config.MetricsSampleInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.SampleSizes(ctx, c.config.MetricsHotRecipients); err != nil {
+			c.logger.WarnContext(ctx, "failed to sample sorted set sizes", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SampleSizes records the sizes of the "liked" and "new_liked" sets of recipients in a
+// single pipelined round-trip.
+func (c *Cache) SampleSizes(ctx context.Context, recipients []string) error {
+	liked := make([]*redis.IntCmd, len(recipients))
+	newLiked := make([]*redis.IntCmd, len(recipients))
+
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, recipientID := range recipients {
+			liked[i] = pipe.ZCard(ctx, likedKey(recipientID))
+			newLiked[i] = pipe.ZCard(ctx, newLikedKey(recipientID))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, recipientID := range recipients {
+		metrics.LikesSetSize.WithLabelValues(recipientID, "liked").Set(float64(liked[i].Val()))
+		metrics.LikesSetSize.WithLabelValues(recipientID, "new_liked").Set(float64(newLiked[i].Val()))
+	}
+	return nil
+}
@@ -2,81 +2,401 @@ package repository
 
 import (
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/logging"
+	"github.com/endyapina/muzzapp/internal/metrics"
 	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/pagination"
 
 	pb "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
 
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
 )
 
 type DBRepository struct {
 	db     *gorm.DB
 	config *config.AppConfig
+	logger *slog.Logger
+	now    func() time.Time
 }
 
 type Liker = pb.ListLikedYouResponse_Liker
 
+type Match = pb.ListMatchesResponse_Match
+
+type Blocked = pb.ListBlockedResponse_Blocked
+
 func New(db *gorm.DB, config *config.AppConfig) (*DBRepository, error) {
 	if config == nil {
 		return nil, errors.New("database config is required")
 	}
-	return &DBRepository{db: db, config: config}, nil
+	if !validLikeTimestampMode(config.LikeTimestampMode) {
+		return nil, fmt.Errorf("unknown like timestamp mode %q", config.LikeTimestampMode)
+	}
+	if err := metrics.RegisterGORMCallbacks(db); err != nil {
+		return nil, fmt.Errorf("failed to register metrics callbacks: %w", err)
+	}
+	// query variables hold user ids, so only the statements are attached to the spans
+	if err := db.Use(otelgorm.NewPlugin(otelgorm.WithoutMetrics(), otelgorm.WithoutQueryVariables())); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+	return &DBRepository{
+		db:     db,
+		config: config,
+		logger: slog.Default().With("component", "repository"),
+		now:    time.Now,
+	}, nil
+}
+
+// validLikeTimestampMode reports whether mode is a known LikeTimestampMode. an empty mode
+// behaves like "first".
+func validLikeTimestampMode(mode string) bool {
+	return mode == "" || mode == config.LikeTimestampFirst || mode == config.LikeTimestampBump
+}
+
+// maxTxRetries bounds how often a transaction is retried after losing a deadlock.
+const maxTxRetries = 5
+
+var (
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent again with a
+	// different decision.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for another decision")
+
+	// ErrRelayBusy is returned by TryReplayOutbox when the relay or another rebuild holds
+	// the relay lock.
+	ErrRelayBusy = errors.New("outbox relay lock is held")
+
+	// errKeyTaken rolls back a decision whose idempotency key a concurrent call stored first
+	errKeyTaken = errors.New("idempotency key taken")
+)
+
+// Outcome is what recording a decision did to the pair.
+type Outcome struct {
+	// Mutual reports whether the two users like each other after the decision
+	Mutual bool
+	// Changed reports whether the decision added or withdrew the actor's like. a repeated
+	// like, a pass of someone who wasn't liked and a replayed decision change nothing
+	Changed bool
+	// Timestamp is the time the decision in force is stored with, the actor's score in the
+	// recipient's sorted sets. a repeated like in the "first" LikeTimestampMode keeps the
+	// time of the like it repeats. unset for a replayed decision or a blocked pair
+	Timestamp int64
+}
+
+// RecordDecision stores the decision together with the outbox events that mirror it into
+// the redis cache and reports whether the two users now like each other, all in a single
+// transaction. the match itself is recorded when the second like lands and removed as
+// soon as either user passes.
+//
+// after writing its own row the transaction takes a locking read on the reverse pair.
+// when two users like each other concurrently each transaction holds its own row and waits
+// for the other's, so mysql aborts one of them as a deadlock; it is retried and then sees
+// the committed like. this way exactly one of two racing likes reports the match.
+//
+// with an idempotency key the outcome is stored in the same transaction, and a call
+// repeating the key returns the stored outcome without recording anything. two calls
+// racing with the same key both record the decision, but only the first one to commit
+// keeps it: the other one conflicts on the key, rolls back and replays the first.
+func (r *DBRepository) RecordDecision(ctx context.Context, actorID, recipientID string, liked bool, idempotencyKey string) (Outcome, error) {
+	if idempotencyKey != "" {
+		if outcome, found, err := r.replayDecision(ctx, actorID, recipientID, liked, idempotencyKey); found || err != nil {
+			return outcome, err
+		}
+	}
+
+	var outcome Outcome
+	err := r.withTx(ctx, func(tx *gorm.DB) error {
+		var err error
+		outcome, err = r.recordDecision(tx, models.DecisionEvent{
+			ActorUserID:     actorID,
+			RecipientUserID: recipientID,
+			Liked:           liked,
+			Source:          models.DecisionSourceAPI,
+			RequestID:       logging.RequestID(ctx),
+			UnixTimestamp:   r.now().Unix(),
+		})
+		if err != nil || idempotencyKey == "" {
+			return err
+		}
+
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.IdempotencyKey{
+			ActorUserID:     actorID,
+			Key:             idempotencyKey,
+			RecipientUserID: recipientID,
+			Liked:           liked,
+			MutualLikes:     outcome.Mutual,
+			UnixTimestamp:   r.now().Unix(),
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return errKeyTaken
+		}
+		return nil
+	})
+	if errors.Is(err, errKeyTaken) {
+		outcome, _, err = r.replayDecision(ctx, actorID, recipientID, liked, idempotencyKey)
+		return outcome, err
+	}
+	if err != nil {
+		return Outcome{}, err
+	}
+	return outcome, nil
+}
+
+// replayDecision looks up the stored outcome of an idempotency key. a key repeated with
+// another decision is rejected rather than replayed, it most likely is a client bug.
+func (r *DBRepository) replayDecision(ctx context.Context, actorID, recipientID string, liked bool, idempotencyKey string) (Outcome, bool, error) {
+	var stored models.IdempotencyKey
+	err := r.db.WithContext(ctx).Where("actor_user_id = ? AND idempotency_key = ?", actorID, idempotencyKey).Take(&stored).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return Outcome{}, false, nil
+	case err != nil:
+		return Outcome{}, false, err
+	case stored.RecipientUserID != recipientID || stored.Liked != liked:
+		return Outcome{}, true, ErrIdempotencyKeyReused
+	}
+	r.logger.DebugContext(ctx, "decision replayed", "actor_id", actorID, "idempotency_key", idempotencyKey)
+	return Outcome{Mutual: stored.MutualLikes}, true, nil
+}
+
+// BatchDecision is a decision of an actor's batch, see RecordDecisions.
+type BatchDecision struct {
+	RecipientID string
+	Liked       bool
+}
+
+// RecordDecisions records a batch of decisions of one actor in order, in a single
+// transaction, and reports the outcome of each of them right after it. every decision is
+// recorded exactly like RecordDecision does, so the relay mirrors the whole batch into
+// redis from the outbox in one go.
+//
+// the batch commits or fails as a whole: a deadlock retries every decision of it.
+func (r *DBRepository) RecordDecisions(ctx context.Context, actorID string, decisions []BatchDecision) ([]Outcome, error) {
+	outcomes := make([]Outcome, len(decisions))
+
+	err := r.withTx(ctx, func(tx *gorm.DB) error {
+		now := r.now().Unix()
+		for i, d := range decisions {
+			var err error
+			outcomes[i], err = r.recordDecision(tx, models.DecisionEvent{
+				ActorUserID:     actorID,
+				RecipientUserID: d.RecipientID,
+				Liked:           d.Liked,
+				Source:          models.DecisionSourceBatch,
+				RequestID:       logging.RequestID(ctx),
+				UnixTimestamp:   now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outcomes, nil
 }
 
-func (r *DBRepository) UpsertDecision(ctx context.Context, actorID, recipientID string, liked bool) error {
-	return r.db.WithContext(ctx).Save(&models.Decision{
+// recordDecision writes a single decision inside tx, see RecordDecision. the decision is
+// appended to the history and replaces the latest decision of the pair.
+//
+// in the "first" LikeTimestampMode a repeated like keeps the time of the like it repeats,
+// so the liker keeps their place in the recipient's listing and outstanding cursors stay
+// valid. the history still records when each like was made.
+func (r *DBRepository) recordDecision(tx *gorm.DB, decision models.DecisionEvent) (Outcome, error) {
+	actorID, recipientID, liked, now := decision.ActorUserID, decision.RecipientUserID, decision.Liked, decision.UnixTimestamp
+
+	if err := tx.Create(&decision).Error; err != nil {
+		return Outcome{}, err
+	}
+
+	// a plain read is enough: the row is write-locked by the Save right after, and a
+	// locking read of a missing row would take a gap lock blocking unrelated likes
+	var previous models.Decision
+	err := tx.Where("actor_user_id = ? AND recipient_user_id = ?", actorID, recipientID).Take(&previous).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return Outcome{}, err
+	}
+	likedBefore := err == nil && previous.Liked
+	if liked && likedBefore && r.config.LikeTimestampMode != config.LikeTimestampBump {
+		now = previous.UnixTimestamp
+	}
+
+	if err := tx.Save(&models.Decision{
 		ActorUserID:     actorID,
 		RecipientUserID: recipientID,
 		Liked:           liked,
-		UnixTimestamp:   time.Now().Unix(),
-	}).Error
+		UnixTimestamp:   now,
+	}).Error; err != nil {
+		return Outcome{}, err
+	}
+
+	mutual, blocked, err := syncPair(tx, actorID, recipientID, liked, now)
+	if err != nil || blocked {
+		return Outcome{}, err
+	}
+	return Outcome{Mutual: mutual, Changed: liked != likedBefore, Timestamp: now}, nil
 }
 
-func (r *DBRepository) CheckMutualLike(ctx context.Context, actorID, recipientID string) (bool, error) {
-	var count int64
+// syncPair brings the sorted sets and the match of a pair in line with the decision of
+// actor about recipient, just written in tx with its time ts, and reports whether the two
+// now like each other and whether they are blocked. a decision that doesn't exist is
+// synced as a pass. the reverse decision is read with a locking read, see RecordDecision.
+func syncPair(tx *gorm.DB, actorID, recipientID string, liked bool, ts int64) (bool, bool, error) {
+	var reverse *models.Decision
+	var found models.Decision
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("actor_user_id = ? AND recipient_user_id = ?", recipientID, actorID).
+		Take(&found).Error
+	switch {
+	case err == nil:
+		reverse = &found
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return false, false, err
+	}
 
-	// count both decisions: actor liked recipient AND recipient liked actor
-	err := r.db.WithContext(ctx).Model(&models.Decision{}).
-		Where("(actor_user_id = ? AND recipient_user_id = ? AND liked = ?) OR (actor_user_id = ? AND recipient_user_id = ? AND liked = ?)",
-			actorID, recipientID, true,
-			recipientID, actorID, true,
-		).Count(&count).Error
+	// the decision of a blocked pair is kept, so it comes back once the block is lifted,
+	// but it neither reaches the cache nor makes a match. the read is locking so that a
+	// concurrent block waits for this transaction and its outbox events come after ours
+	blocked, err := isBlocked(tx.Clauses(clause.Locking{Strength: "SHARE"}), actorID, recipientID)
 	if err != nil {
-		return false, err
+		return false, false, err
+	}
+	if blocked {
+		if err := tx.Create(blockOutboxEvents(actorID, recipientID, ts)).Error; err != nil {
+			return false, true, err
+		}
+		return false, true, deleteMatch(tx, actorID, recipientID)
+	}
+
+	if err := tx.Create(pairOutboxEvents(actorID, recipientID, liked, ts, reverse)).Error; err != nil {
+		return false, false, err
 	}
 
-	// if count == 2, then it is mutual
-	return count == 2, nil
+	if !liked || reverse == nil || !reverse.Liked {
+		return false, false, deleteMatch(tx, actorID, recipientID)
+	}
+
+	// a repeated like keeps the time the match was first made
+	matchedAt := max(ts, reverse.UnixTimestamp)
+	return true, false, tx.Clauses(clause.OnConflict{DoNothing: true}).Create([]models.Match{
+		{UserID: actorID, MatchedUserID: recipientID, UnixTimestamp: matchedAt},
+		{UserID: recipientID, MatchedUserID: actorID, UnixTimestamp: matchedAt},
+	}).Error
 }
 
-// GetLikers returns likers of a recipient with optional pagination
-func (r *DBRepository) GetLikers(ctx context.Context, recipientID string, paginationToken string) ([]Liker, string, error) {
-	pageSize := int(r.config.PaginationSize)
-	var likers []Liker
-	query := r.db.WithContext(ctx).Where("recipient_user_id = ? AND liked = ?", recipientID, true).Order("unix_timestamp ASC, actor_user_id ASC").Limit(pageSize + 1)
+// pairOutboxEvents returns the cache writes that bring both users' sorted sets in line
+// with the decision actor -> recipient and the reverse decision, if any:
+//
+//   - liked:<recipient> holds the actor iff the actor likes the recipient
+//   - new_liked:<recipient> holds the actor iff the actor likes the recipient and the
+//     recipient doesn't like the actor back
+//   - new_liked:<actor> holds the recipient iff the recipient likes the actor and the
+//     actor doesn't like the recipient back
+//
+// the events describe the resulting state rather than the change, so replaying them is
+// always safe.
+func pairOutboxEvents(actorID, recipientID string, liked bool, ts int64, reverse *models.Decision) []models.OutboxEvent {
+	likedBack := reverse != nil && reverse.Liked
 
-	if paginationToken != "" {
-		ts, actor, err := decodePaginationToken(paginationToken)
-		if err != nil {
-			return nil, "", err
+	event := func(op, recipientID, actorID string, ts int64) models.OutboxEvent {
+		return models.OutboxEvent{Op: op, RecipientUserID: recipientID, ActorUserID: actorID, UnixTimestamp: ts}
+	}
+
+	var events []models.OutboxEvent
+	if liked {
+		events = append(events, event(models.OutboxAddLike, recipientID, actorID, ts))
+	} else {
+		events = append(events, event(models.OutboxRemoveLike, recipientID, actorID, ts))
+	}
+
+	if liked && !likedBack {
+		events = append(events, event(models.OutboxAddNewLike, recipientID, actorID, ts))
+	} else {
+		events = append(events, event(models.OutboxRemoveNewLike, recipientID, actorID, ts))
+	}
+
+	if likedBack && !liked {
+		events = append(events, event(models.OutboxAddNewLike, actorID, recipientID, reverse.UnixTimestamp))
+	} else {
+		events = append(events, event(models.OutboxRemoveNewLike, actorID, recipientID, ts))
+	}
+
+	return events
+}
+
+// blockOutboxEvents returns the cache writes removing two users from each other's sorted
+// sets, whatever they decided about each other.
+func blockOutboxEvents(userID, otherUserID string, ts int64) []models.OutboxEvent {
+	var events []models.OutboxEvent
+	for _, pair := range [][2]string{{userID, otherUserID}, {otherUserID, userID}} {
+		for _, op := range []string{models.OutboxRemoveLike, models.OutboxRemoveNewLike} {
+			events = append(events, models.OutboxEvent{Op: op, RecipientUserID: pair[0], ActorUserID: pair[1], UnixTimestamp: ts})
 		}
-		query = query.Where("(unix_timestamp > ?) OR (unix_timestamp = ? AND actor_user_id > ?)", ts, ts, actor)
 	}
+	return events
+}
+
+// deleteMatch removes the match between two users, if any.
+func deleteMatch(tx *gorm.DB, userID, otherUserID string) error {
+	return tx.Where("(user_id = ? AND matched_user_id = ?) OR (user_id = ? AND matched_user_id = ?)",
+		userID, otherUserID, otherUserID, userID).
+		Delete(&models.Match{}).Error
+}
+
+// withTx runs fn in a transaction, retrying it when mysql picks it as a deadlock victim
+// or it times out waiting for a row lock.
+func (r *DBRepository) withTx(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	var err error
+	for attempt := 0; attempt < maxTxRetries; attempt++ {
+		err = r.db.WithContext(ctx).Transaction(fn)
+		if !isRetryable(err) {
+			return err
+		}
+		r.logger.DebugContext(ctx, "retrying transaction", "attempt", attempt+1, "error", err)
+	}
+	r.logger.WarnContext(ctx, "transaction still conflicting after retries", "attempts", maxTxRetries, "error", err)
+	return err
+}
+
+// isRetryable reports whether err is a mysql deadlock (1213) or lock wait timeout (1205).
+func isRetryable(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == 1213 || mysqlErr.Number == 1205
+	}
+	return false
+}
+
+// GetLikers returns a page of likers of a recipient in the page's order and time window,
+// starting strictly after its cursor, and whether there are more likers after the page
+func (r *DBRepository) GetLikers(ctx context.Context, recipientID string, page pagination.Page) ([]Liker, bool, error) {
+	pageSize := page.Limit(r.config.PaginationSize)
+	var likers []Liker
+	query := r.db.WithContext(ctx).Where("recipient_user_id = ? AND liked = ?", recipientID, true).
+		Where(notBlocked("decisions.recipient_user_id", "decisions.actor_user_id"))
+	query = paginate(query, "unix_timestamp", "actor_user_id", page, pageSize)
 
 	var results []models.Decision
 	if err := query.Find(&results).Error; err != nil {
-		return nil, "", err
+		return nil, false, err
 	}
 
-	nextToken := ""
-	if len(results) > pageSize {
-		nextToken = encodePaginationToken(results[pageSize].UnixTimestamp, results[pageSize].ActorUserID)
+	more := len(results) > pageSize
+	if more {
 		results = results[:pageSize]
 	}
 
@@ -87,45 +407,39 @@ func (r *DBRepository) GetLikers(ctx context.Context, recipientID string, pagina
 		})
 	}
 
-	return likers, nextToken, nil
+	return likers, more, nil
 }
 
 // CountLikes returns number of likes a recipient has
 func (r *DBRepository) CountLikes(ctx context.Context, recipientID string) (uint64, error) {
 	var count int64
-	if err := r.db.WithContext(ctx).Model(&models.Decision{}).Where("recipient_user_id = ? AND liked = ?", recipientID, true).Count(&count).Error; err != nil {
+	if err := r.db.WithContext(ctx).Model(&models.Decision{}).
+		Where("recipient_user_id = ? AND liked = ?", recipientID, true).
+		Where(notBlocked("decisions.recipient_user_id", "decisions.actor_user_id")).
+		Count(&count).Error; err != nil {
 		return 0, err
 	}
 	return uint64(count), nil
 }
 
 // GetNewLikers excludes users who the recipient has already liked
-func (r *DBRepository) GetNewLikers(ctx context.Context, recipientID string, paginationToken string) ([]Liker, string, error) {
-	pageSize := int(r.config.PaginationSize)
+func (r *DBRepository) GetNewLikers(ctx context.Context, recipientID string, page pagination.Page) ([]Liker, bool, error) {
+	pageSize := page.Limit(r.config.PaginationSize)
 	var likers []Liker
 	query := r.db.WithContext(ctx).Table("decisions as d1").
 		Select("d1.actor_user_id, d1.unix_timestamp").
 		Joins("LEFT JOIN decisions as d2 ON d1.actor_user_id = d2.recipient_user_id AND d2.actor_user_id = ?", recipientID).
 		Where("d1.recipient_user_id = ? AND d1.liked = ? AND (d2.liked IS NULL OR d2.liked = ?)", recipientID, true, false).
-		Order("d1.unix_timestamp ASC, d1.actor_user_id ASC").
-		Limit(pageSize + 1)
-
-	if paginationToken != "" {
-		ts, actor, err := decodePaginationToken(paginationToken)
-		if err != nil {
-			return nil, "", err
-		}
-		query = query.Where("(d1.unix_timestamp > ?) OR (d1.unix_timestamp = ? AND d1.actor_user_id > ?)", ts, ts, actor)
-	}
+		Where(notBlocked("d1.recipient_user_id", "d1.actor_user_id"))
+	query = paginate(query, "d1.unix_timestamp", "d1.actor_user_id", page, pageSize)
 
 	var results []models.Decision
 	if err := query.Scan(&results).Error; err != nil {
-		return nil, "", err
+		return nil, false, err
 	}
 
-	nextToken := ""
-	if len(results) > pageSize {
-		nextToken = encodePaginationToken(results[pageSize].UnixTimestamp, results[pageSize].ActorUserID)
+	more := len(results) > pageSize
+	if more {
 		results = results[:pageSize]
 	}
 
@@ -136,32 +450,407 @@ func (r *DBRepository) GetNewLikers(ctx context.Context, recipientID string, pag
 		})
 	}
 
-	return likers, nextToken, nil
+	return likers, more, nil
 }
 
-// HasRecipientLikedActor checks if recipient has liked the actor
-func (r *DBRepository) HasRecipientLikedActor(ctx context.Context, recipientID, actorID string) (bool, error) {
-	var decision models.Decision
-	err := r.db.WithContext(ctx).First(&decision, "actor_user_id = ? AND recipient_user_id = ? AND liked = ?", recipientID, actorID, true).Error
-	if err == gorm.ErrRecordNotFound {
-		return false, nil
+// ListMatches returns a page of the matches of a user, oldest first, starting strictly
+// after the cursor, and whether there are more matches after the page
+func (r *DBRepository) ListMatches(ctx context.Context, userID string, after *pagination.Cursor) ([]Match, bool, error) {
+	pageSize := int(r.config.PaginationSize)
+	var matches []Match
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	query = paginate(query, "unix_timestamp", "matched_user_id", pagination.Page{After: after, Direction: pagination.Ascending}, pageSize)
+
+	var results []models.Match
+	if err := query.Find(&results).Error; err != nil {
+		return nil, false, err
+	}
+
+	more := len(results) > pageSize
+	if more {
+		results = results[:pageSize]
 	}
-	return err == nil, err
+
+	for _, m := range results {
+		matches = append(matches, Match{
+			UserId:        m.MatchedUserID,
+			UnixTimestamp: uint64(m.UnixTimestamp),
+		})
+	}
+
+	return matches, more, nil
 }
 
-// Helper functions for encoding/decoding pagination tokens
-func encodePaginationToken(ts int64, actorID string) string {
-	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%d|%s", ts, actorID)))
+// CountMatches returns number of matches a user has
+func (r *DBRepository) CountMatches(ctx context.Context, userID string) (uint64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Match{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return uint64(count), nil
 }
 
-func decodePaginationToken(token string) (int64, string, error) {
-	bytes, err := base64.StdEncoding.DecodeString(token)
+// GetDecisionHistory returns a page of the decisions of an actor, newest first, optionally
+// only those about a single recipient. the cursor holds the id of the last event of the
+// previous page.
+func (r *DBRepository) GetDecisionHistory(ctx context.Context, actorID, recipientID string, after *pagination.Cursor) ([]models.DecisionEvent, bool, error) {
+	pageSize := int(r.config.PaginationSize)
+	query := r.db.WithContext(ctx).Where("actor_user_id = ?", actorID).Order("id DESC").Limit(pageSize + 1)
+	if recipientID != "" {
+		query = query.Where("recipient_user_id = ?", recipientID)
+	}
+	if after != nil {
+		id, err := strconv.ParseUint(after.ID, 10, 64)
+		if err != nil {
+			return nil, false, pagination.ErrInvalidCursor
+		}
+		query = query.Where("id < ?", id)
+	}
+
+	var events []models.DecisionEvent
+	if err := query.Find(&events).Error; err != nil {
+		return nil, false, err
+	}
+
+	more := len(events) > pageSize
+	if more {
+		events = events[:pageSize]
+	}
+	return events, more, nil
+}
+
+// ScannedLike is a like together with whether the recipient liked the actor back.
+type ScannedLike struct {
+	ActorUserID     string
+	RecipientUserID string
+	UnixTimestamp   int64
+	LikedBack       bool
+}
+
+// ScanLikes returns up to limit likes ordered by recipient and then actor, starting
+// strictly after the (afterRecipientID, afterActorID) key. It is used to stream the whole
+// likes table in batches when rebuilding the redis cache. An empty recipientID scans every
+// recipient, otherwise only the likes received by that recipient are returned.
+func (r *DBRepository) ScanLikes(ctx context.Context, recipientID, afterRecipientID, afterActorID string, limit int) ([]ScannedLike, error) {
+	query := r.db.WithContext(ctx).Table("decisions as d1").
+		Select("d1.actor_user_id, d1.recipient_user_id, d1.unix_timestamp, COALESCE(d2.liked, ?) AS liked_back", false).
+		Joins("LEFT JOIN decisions as d2 ON d1.actor_user_id = d2.recipient_user_id AND d1.recipient_user_id = d2.actor_user_id").
+		Where("d1.liked = ?", true).
+		Where(notBlocked("d1.recipient_user_id", "d1.actor_user_id")).
+		Order("d1.recipient_user_id ASC, d1.actor_user_id ASC").
+		Limit(limit)
+
+	if recipientID != "" {
+		query = query.Where("d1.recipient_user_id = ?", recipientID)
+	}
+	if afterRecipientID != "" || afterActorID != "" {
+		query = query.Where("(d1.recipient_user_id > ?) OR (d1.recipient_user_id = ? AND d1.actor_user_id > ?)", afterRecipientID, afterRecipientID, afterActorID)
+	}
+
+	var results []ScannedLike
+	if err := query.Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ProcessOutbox claims up to limit pending outbox events in insertion order and hands them
+// to apply. events are marked processed when apply succeeds; otherwise their attempt count
+// and last error are recorded and they stay pending. the relay lock is held for the
+// duration of apply, so relays running on several replicas process events one batch at a
+// time and never reorder mutations of the same key.
+func (r *DBRepository) ProcessOutbox(ctx context.Context, limit int, apply func([]models.OutboxEvent) error) (int, error) {
+	var count int
+	var applyErr error
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockRelay(tx); err != nil {
+			return err
+		}
+
+		var events []models.OutboxEvent
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("processed_at IS NULL").
+			Order("id ASC").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(events))
+		for i, e := range events {
+			ids[i] = e.ID
+		}
+
+		// the failure is recorded and committed rather than rolled back,
+		// the events simply stay pending for the next attempt
+		if applyErr = apply(events); applyErr != nil {
+			return tx.Model(&models.OutboxEvent{}).Where("id IN ?", ids).Updates(map[string]any{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": applyErr.Error(),
+			}).Error
+		}
+
+		count = len(events)
+		return tx.Model(&models.OutboxEvent{}).Where("id IN ?", ids).Update("processed_at", time.Now().Unix()).Error
+	})
 	if err != nil {
-		return 0, "", err
+		return 0, err
+	}
+	return count, applyErr
+}
+
+// OutboxHighWater returns the id of the latest outbox event, or zero when there is none. a
+// cache rebuild reads it before its snapshot of the decisions, see ReplayOutbox.
+func (r *DBRepository) OutboxHighWater(ctx context.Context) (uint64, error) {
+	var id uint64
+	err := r.db.WithContext(ctx).Model(&models.OutboxEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&id).Error
+	return id, err
+}
+
+// ReplayOutbox hands the outbox events of a recipient after afterID, relayed or not, to
+// apply while holding the relay lock.
+//
+// a cache rebuild writes a snapshot of the decisions that may miss mutations the relay
+// applied while it was being read. replaying every event since the high-water mark taken
+// before the snapshot, in the same redis transaction as the snapshot, brings them back;
+// events the snapshot already holds are idempotent. with the lock held no relay writes in
+// between, and events committed later are relayed on top.
+func (r *DBRepository) ReplayOutbox(ctx context.Context, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error) error {
+	return r.replayOutbox(ctx, lockRelay, recipientID, afterID, apply)
+}
+
+// TryReplayOutbox is ReplayOutbox without the wait: it returns ErrRelayBusy right away
+// when the relay lock is held, so rebuilds on a cache miss don't queue behind the relay
+// and each other.
+func (r *DBRepository) TryReplayOutbox(ctx context.Context, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error) error {
+	return r.replayOutbox(ctx, tryLockRelay, recipientID, afterID, apply)
+}
+
+func (r *DBRepository) replayOutbox(ctx context.Context, lock func(*gorm.DB) error, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lock(tx); err != nil {
+			return err
+		}
+
+		var events []models.OutboxEvent
+		if err := tx.Where("recipient_user_id = ? AND id > ?", recipientID, afterID).
+			Order("id ASC").
+			Find(&events).Error; err != nil {
+			return err
+		}
+		return apply(events)
+	})
+}
+
+// lockRelay takes the relay lock until the end of tx. every write to the likes cache holds
+// it: the relay while it applies a batch, and a rebuild while it swaps a recipient's sets.
+func lockRelay(tx *gorm.DB) error {
+	var locks []models.OutboxRelayLock
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", 1).Find(&locks).Error
+}
+
+// tryLockRelay takes the relay lock like lockRelay, or returns ErrRelayBusy when another
+// transaction holds it: the locked row is skipped rather than waited for.
+func tryLockRelay(tx *gorm.DB) error {
+	var locks []models.OutboxRelayLock
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).Where("id = ?", 1).Find(&locks).Error; err != nil {
+		return err
+	}
+	if len(locks) == 0 {
+		return ErrRelayBusy
+	}
+	return nil
+}
+
+// PurgeOutbox deletes outbox events that were processed before the given unix timestamp.
+func (r *DBRepository) PurgeOutbox(ctx context.Context, before int64) (int64, error) {
+	res := r.db.WithContext(ctx).Where("processed_at < ?", before).Delete(&models.OutboxEvent{})
+	return res.RowsAffected, res.Error
+}
+
+// PurgeIdempotencyKeys deletes the idempotency keys stored before the given unix timestamp
+// and returns how many were deleted. a retry arriving after that records the decision
+// again.
+func (r *DBRepository) PurgeIdempotencyKeys(ctx context.Context, before int64) (int64, error) {
+	res := r.db.WithContext(ctx).Where("unix_timestamp < ?", before).Delete(&models.IdempotencyKey{})
+	return res.RowsAffected, res.Error
+}
+
+// BlockOutcome is what blocking a user hid.
+type BlockOutcome struct {
+	// Hidden are the likes between the two users the block hid from their recipients, none
+	// when an earlier block already hid them
+	Hidden []models.Decision
+	// Timestamp is the time of the block
+	Timestamp int64
+}
+
+// Block records that blocker blocked blocked, with the reason they reported, and hides
+// the two users from each other: their likes leave both users' sorted sets and their match
+// is removed. the decisions themselves are kept for Unblock. blocking a user again keeps
+// the first block.
+func (r *DBRepository) Block(ctx context.Context, blockerID, blockedID, reason string) (BlockOutcome, error) {
+	var outcome BlockOutcome
+	err := r.withTx(ctx, func(tx *gorm.DB) error {
+		now := r.now().Unix()
+		outcome = BlockOutcome{Timestamp: now}
+
+		blocked, err := isBlocked(tx.Clauses(clause.Locking{Strength: "SHARE"}), blockerID, blockedID)
+		if err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Block{
+			BlockerUserID: blockerID,
+			BlockedUserID: blockedID,
+			Reason:        reason,
+			UnixTimestamp: now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(blockOutboxEvents(blockerID, blockedID, now)).Error; err != nil {
+			return err
+		}
+		if err := deleteMatch(tx, blockerID, blockedID); err != nil || blocked {
+			return err
+		}
+
+		// locked like in Unblock, so a like racing with the block is either hidden here or
+		// recorded as blocked after it
+		return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("((actor_user_id = ? AND recipient_user_id = ?) OR (actor_user_id = ? AND recipient_user_id = ?)) AND liked = ?",
+				blockerID, blockedID, blockedID, blockerID, true).
+			Order("actor_user_id ASC").
+			Find(&outcome.Hidden).Error
+	})
+	if err != nil {
+		return BlockOutcome{}, err
+	}
+	return outcome, nil
+}
+
+// Unblock lifts the block of blocker on blocked. unless the other user blocked them too,
+// the pair's decisions are mirrored into the cache again and their match, if they like
+// each other, is restored as of the later of the two likes.
+func (r *DBRepository) Unblock(ctx context.Context, blockerID, blockedID string) error {
+	return r.withTx(ctx, func(tx *gorm.DB) error {
+		res := tx.Where("blocker_user_id = ? AND blocked_user_id = ?", blockerID, blockedID).Delete(&models.Block{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		blocked, err := isBlocked(tx.Clauses(clause.Locking{Strength: "SHARE"}), blockerID, blockedID)
+		if err != nil || blocked {
+			return err
+		}
+
+		// both rows are locked, so a decision racing with the unblock is mirrored after it
+		var decisions []models.Decision
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("(actor_user_id = ? AND recipient_user_id = ?) OR (actor_user_id = ? AND recipient_user_id = ?)",
+				blockerID, blockedID, blockedID, blockerID).
+			Find(&decisions).Error; err != nil {
+			return err
+		}
+		var given, received *models.Decision
+		for i := range decisions {
+			if decisions[i].ActorUserID == blockerID {
+				given = &decisions[i]
+			} else {
+				received = &decisions[i]
+			}
+		}
+
+		var events []models.OutboxEvent
+		if given != nil {
+			events = append(events, pairOutboxEvents(blockerID, blockedID, given.Liked, given.UnixTimestamp, received)...)
+		}
+		if received != nil {
+			events = append(events, pairOutboxEvents(blockedID, blockerID, received.Liked, received.UnixTimestamp, given)...)
+		}
+		if len(events) > 0 {
+			if err := tx.Create(events).Error; err != nil {
+				return err
+			}
+		}
+
+		if given == nil || received == nil || !given.Liked || !received.Liked {
+			return nil
+		}
+		matchedAt := max(given.UnixTimestamp, received.UnixTimestamp)
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create([]models.Match{
+			{UserID: blockerID, MatchedUserID: blockedID, UnixTimestamp: matchedAt},
+			{UserID: blockedID, MatchedUserID: blockerID, UnixTimestamp: matchedAt},
+		}).Error
+	})
+}
+
+// ListBlocked returns a page of the users blocked by a user, oldest block first, starting
+// strictly after the cursor, and whether there are more blocked users after the page
+func (r *DBRepository) ListBlocked(ctx context.Context, blockerID string, after *pagination.Cursor) ([]Blocked, bool, error) {
+	pageSize := int(r.config.PaginationSize)
+	var blocked []Blocked
+	query := r.db.WithContext(ctx).Where("blocker_user_id = ?", blockerID)
+	query = paginate(query, "unix_timestamp", "blocked_user_id", pagination.Page{After: after, Direction: pagination.Ascending}, pageSize)
+
+	var results []models.Block
+	if err := query.Find(&results).Error; err != nil {
+		return nil, false, err
+	}
+
+	more := len(results) > pageSize
+	if more {
+		results = results[:pageSize]
+	}
+
+	for _, b := range results {
+		blocked = append(blocked, Blocked{
+			UserId:        b.BlockedUserID,
+			UnixTimestamp: uint64(b.UnixTimestamp),
+		})
+	}
+
+	return blocked, more, nil
+}
+
+// isBlocked reports whether either of two users blocked the other.
+func isBlocked(db *gorm.DB, userID, otherUserID string) (bool, error) {
+	var count int64
+	err := db.Model(&models.Block{}).
+		Where("(blocker_user_id = ? AND blocked_user_id = ?) OR (blocker_user_id = ? AND blocked_user_id = ?)",
+			userID, otherUserID, otherUserID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// notBlocked is a condition keeping the rows whose two user columns aren't blocked in
+// either direction. the primary key of blocks answers both lookups.
+func notBlocked(userColumn, otherUserColumn string) string {
+	return fmt.Sprintf("NOT EXISTS (SELECT 1 FROM blocks WHERE (blocks.blocker_user_id = %[1]s AND blocks.blocked_user_id = %[2]s)"+
+		" OR (blocks.blocker_user_id = %[2]s AND blocks.blocked_user_id = %[1]s))", userColumn, otherUserColumn)
+}
+
+// paginate orders a query by (tsColumn, idColumn) in the page's direction, keeps it to the
+// page's time window and starts it strictly after the page's cursor. one row more than
+// pageSize is fetched, so callers can tell whether another page follows.
+func paginate(query *gorm.DB, tsColumn, idColumn string, page pagination.Page, pageSize int) *gorm.DB {
+	order, cmp := "ASC", ">"
+	if page.Descending() {
+		order, cmp = "DESC", "<"
+	}
+	query = query.Order(fmt.Sprintf("%s %s, %s %s", tsColumn, order, idColumn, order)).Limit(pageSize + 1)
+
+	if page.Since > 0 {
+		query = query.Where(tsColumn+" >= ?", page.Since)
+	}
+	if page.Until > 0 {
+		query = query.Where(tsColumn+" <= ?", page.Until)
+	}
+	if page.After != nil {
+		query = query.Where(fmt.Sprintf("(%[1]s %[3]s ?) OR (%[1]s = ? AND %[2]s %[3]s ?)", tsColumn, idColumn, cmp),
+			page.After.Timestamp, page.After.Timestamp, page.After.ID)
 	}
-	parts := string(bytes)
-	var ts int64
-	var actor string
-	_, err = fmt.Sscanf(parts, "%d|%s", &ts, &actor)
-	return ts, actor, err
+	return query
 }
@@ -0,0 +1,806 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/database"
+	"github.com/endyapina/muzzapp/internal/logging"
+	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/pagination"
+)
+
+// newTestRepository returns a repository backed by the mysql server in
+// MUZZAPP_TEST_MYSQL_DSN, or by an embedded sqlite database when it isn't set so the
+// tests run without any infrastructure.
+func newTestRepository(t *testing.T) *DBRepository {
+	t.Helper()
+
+	var dialector gorm.Dialector
+	dsn := os.Getenv("MUZZAPP_TEST_MYSQL_DSN")
+	if dsn != "" {
+		dialector = mysql.Open(dsn)
+	} else {
+		// immediate transactions make sqlite serialize concurrent writers the
+		// way row locks serialize a contended pair in mysql
+		path := filepath.Join(t.TempDir(), "muzzapp.db")
+		dialector = sqlite.Open(fmt.Sprintf("file:%s?_txlock=immediate&_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)", path))
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	// mysql gets the real migrations, the mysql specific DDL doesn't run on sqlite
+	tables := []any{&models.Decision{}, &models.OutboxEvent{}, &models.Match{}, &models.DecisionEvent{}, &models.IdempotencyKey{}, &models.Block{}, &models.ExpiredPass{}}
+	if dsn != "" {
+		migrator, err := database.NewMigrator(db, database.Migrations)
+		require.NoError(t, err)
+		_, err = migrator.Up(context.Background())
+		require.NoError(t, err)
+	} else {
+		// mysql seeds the relay lock row in its migration, so it stays out of tables
+		require.NoError(t, db.AutoMigrate(append(tables, &models.OutboxRelayLock{})...))
+		require.NoError(t, db.Create(&models.OutboxRelayLock{ID: 1}).Error)
+	}
+
+	// a shared mysql keeps rows between runs
+	for _, table := range tables {
+		require.NoError(t, db.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(table).Error)
+	}
+
+	t.Cleanup(func() {
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	})
+
+	repo, err := New(db, &config.AppConfig{PaginationSize: 50})
+	require.NoError(t, err)
+	return repo
+}
+
+func TestDBRepository_RecordDecision(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	repo.now = func() time.Time { return time.Unix(1000, 0) }
+
+	steps := []struct {
+		actorID     string
+		recipientID string
+		liked       bool
+		want        Outcome
+	}{
+		{actorID: "alice", recipientID: "bob", liked: true, want: Outcome{Mutual: false, Changed: true, Timestamp: 1000}},
+		{actorID: "bob", recipientID: "alice", liked: true, want: Outcome{Mutual: true, Changed: true, Timestamp: 1000}},
+		{actorID: "alice", recipientID: "bob", liked: false, want: Outcome{Mutual: false, Changed: true, Timestamp: 1000}},
+		{actorID: "bob", recipientID: "alice", liked: true, want: Outcome{Mutual: false, Changed: false, Timestamp: 1000}},
+		{actorID: "alice", recipientID: "bob", liked: true, want: Outcome{Mutual: true, Changed: true, Timestamp: 1000}},
+		{actorID: "carol", recipientID: "bob", liked: false, want: Outcome{Mutual: false, Changed: false, Timestamp: 1000}},
+	}
+
+	for i, step := range steps {
+		outcome, err := repo.RecordDecision(ctx, step.actorID, step.recipientID, step.liked, "")
+		require.NoError(t, err)
+		assert.Equal(t, step.want, outcome, "step %d", i)
+	}
+
+	// every decision leaves the outbox events that sync both users' sorted sets
+	var events []models.OutboxEvent
+	require.NoError(t, repo.db.Order("id ASC").Find(&events).Error)
+	require.Len(t, events, 3*len(steps))
+
+	type cacheWrite struct{ op, recipientID, actorID string }
+	var got []cacheWrite
+	for _, e := range events[3*2 : 3*4] {
+		got = append(got, cacheWrite{e.Op, e.RecipientUserID, e.ActorUserID})
+	}
+	assert.Equal(t, []cacheWrite{
+		// alice passes bob, bob's like of alice becomes new again
+		{models.OutboxRemoveLike, "bob", "alice"},
+		{models.OutboxRemoveNewLike, "bob", "alice"},
+		{models.OutboxAddNewLike, "alice", "bob"},
+		// bob likes alice again, still unreciprocated
+		{models.OutboxAddLike, "alice", "bob"},
+		{models.OutboxAddNewLike, "alice", "bob"},
+		{models.OutboxRemoveNewLike, "bob", "alice"},
+	}, got)
+}
+
+func TestDBRepository_RecordDecision_IdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	_, err := repo.RecordDecision(ctx, "bob", "alice", true, "")
+	require.NoError(t, err)
+
+	outcome, err := repo.RecordDecision(ctx, "alice", "bob", true, "swipe-1")
+	require.NoError(t, err)
+	require.True(t, outcome.Mutual)
+
+	// bob passing in between doesn't change the outcome of a retry
+	_, err = repo.RecordDecision(ctx, "bob", "alice", false, "")
+	require.NoError(t, err)
+
+	var events, outbox int64
+	require.NoError(t, repo.db.Model(&models.DecisionEvent{}).Count(&events).Error)
+	require.NoError(t, repo.db.Model(&models.OutboxEvent{}).Count(&outbox).Error)
+
+	outcome, err = repo.RecordDecision(ctx, "alice", "bob", true, "swipe-1")
+	require.NoError(t, err)
+	assert.Equal(t, Outcome{Mutual: true}, outcome)
+
+	var replayedEvents, replayedOutbox int64
+	require.NoError(t, repo.db.Model(&models.DecisionEvent{}).Count(&replayedEvents).Error)
+	require.NoError(t, repo.db.Model(&models.OutboxEvent{}).Count(&replayedOutbox).Error)
+	assert.Equal(t, events, replayedEvents, "a replay must not record the decision again")
+	assert.Equal(t, outbox, replayedOutbox, "a replay must not write to the cache again")
+
+	_, err = repo.RecordDecision(ctx, "alice", "carol", true, "swipe-1")
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	// keys are scoped to the actor
+	_, err = repo.RecordDecision(ctx, "carol", "bob", true, "swipe-1")
+	assert.NoError(t, err)
+
+	purged, err := repo.PurgeIdempotencyKeys(ctx, time.Now().Add(time.Hour).Unix())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+}
+
+func TestDBRepository_RecordDecision_ConcurrentRetries(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	const retries = 5
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, retries)
+	for i := range retries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, errs[i] = repo.RecordDecision(ctx, "alice", "bob", true, "swipe-1")
+		}()
+	}
+	close(start)
+	wg.Wait()
+	require.NoError(t, errors.Join(errs...))
+
+	// retries racing the first call roll back, only one decision is kept
+	var events int64
+	require.NoError(t, repo.db.Model(&models.DecisionEvent{}).Count(&events).Error)
+	assert.Equal(t, int64(1), events)
+}
+
+func TestDBRepository_RecordDecisions(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	repo.now = func() time.Time { return time.Unix(1000, 0) }
+
+	_, err := repo.RecordDecision(ctx, "bob", "alice", true, "")
+	require.NoError(t, err)
+	_, err = repo.RecordDecision(ctx, "carol", "alice", true, "")
+	require.NoError(t, err)
+
+	// each flag reflects the pair right after its decision, including repeats in the batch
+	outcomes, err := repo.RecordDecisions(ctx, "alice", []BatchDecision{
+		{RecipientID: "bob", Liked: true},
+		{RecipientID: "carol", Liked: false},
+		{RecipientID: "dave", Liked: true},
+		{RecipientID: "carol", Liked: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Outcome{
+		{Mutual: true, Changed: true, Timestamp: 1000},
+		{Mutual: false, Changed: false, Timestamp: 1000},
+		{Mutual: false, Changed: true, Timestamp: 1000},
+		{Mutual: true, Changed: true, Timestamp: 1000},
+	}, outcomes)
+
+	count, err := repo.CountMatches(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	var events []models.DecisionEvent
+	require.NoError(t, repo.db.Where("actor_user_id = ?", "alice").Find(&events).Error)
+	require.Len(t, events, 4)
+	for _, e := range events {
+		assert.Equal(t, models.DecisionSourceBatch, e.Source)
+	}
+
+	var outbox int64
+	require.NoError(t, repo.db.Model(&models.OutboxEvent{}).Count(&outbox).Error)
+	assert.Equal(t, int64(3*6), outbox)
+}
+
+// on sqlite immediate transactions serialize the two likes, so only mysql exercises the
+// locking read of the reverse pair and the deadlock retries; run it with make test-mysql.
+func TestDBRepository_RecordDecision_ConcurrentMutualLikes(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	const pairs = 25
+
+	for i := 0; i < pairs; i++ {
+		a, b := fmt.Sprintf("a%d", i), fmt.Sprintf("b%d", i)
+
+		var wg sync.WaitGroup
+		start := make(chan struct{})
+		results := make([]Outcome, 2)
+		errs := make([]error, 2)
+
+		for j, pair := range [][2]string{{a, b}, {b, a}} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				results[j], errs[j] = repo.RecordDecision(ctx, pair[0], pair[1], true, "")
+			}()
+		}
+
+		close(start)
+		wg.Wait()
+
+		require.NoError(t, errors.Join(errs...))
+		assert.True(t, results[0].Mutual != results[1].Mutual, "pair %d: exactly one like must report the match, got %v", i, results)
+	}
+}
+
+func TestDBRepository_WithTx_Retries(t *testing.T) {
+	deadlock := &mysqldriver.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	lockWait := &mysqldriver.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"}
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantErr      error
+	}{
+		{name: "no conflict", errs: []error{nil}, wantAttempts: 1},
+		{name: "deadlock then success", errs: []error{deadlock, deadlock, nil}, wantAttempts: 3},
+		{name: "lock wait timeout then success", errs: []error{lockWait, nil}, wantAttempts: 2},
+		{name: "gives up after max retries", errs: []error{deadlock}, wantAttempts: maxTxRetries, wantErr: deadlock},
+		{name: "other errors aren't retried", errs: []error{ErrNothingToUndo}, wantAttempts: 1, wantErr: ErrNothingToUndo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(t)
+
+			// the last error repeats once the list runs out
+			var attempts int
+			err := repo.withTx(context.Background(), func(*gorm.DB) error {
+				err := tt.errs[min(attempts, len(tt.errs)-1)]
+				attempts++
+				return err
+			})
+
+			assert.Equal(t, tt.wantAttempts, attempts)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDBRepository_GetLikers(t *testing.T) {
+	tests := []struct {
+		name   string
+		likes  map[string]int64
+		filter pagination.Page
+		want   []string
+	}{
+		{
+			// five likes landing in the same second must page through without gaps
+			name:  "oldest first within one second",
+			likes: map[string]int64{"user5": 1000, "user3": 1000, "user1": 1000, "user4": 1000, "user2": 1000},
+			want:  []string{"user1", "user2", "user3", "user4", "user5"},
+		},
+		{
+			name:   "newest first",
+			likes:  map[string]int64{"user1": 1000, "user2": 2000, "user3": 2000, "user4": 3000, "user5": 4000},
+			filter: pagination.Page{Direction: pagination.Descending},
+			want:   []string{"user5", "user4", "user3", "user2", "user1"},
+		},
+		{
+			name:   "newest first within a window",
+			likes:  map[string]int64{"user1": 1000, "user2": 2000, "user3": 2000, "user4": 3000, "user5": 4000},
+			filter: pagination.Page{Direction: pagination.Descending, Since: 2000, Until: 3000},
+			want:   []string{"user4", "user3", "user2"},
+		},
+		{
+			name:   "oldest first since a timestamp",
+			likes:  map[string]int64{"user1": 1000, "user2": 2000, "user3": 3000},
+			filter: pagination.Page{Since: 2000},
+			want:   []string{"user2", "user3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := newTestRepository(t)
+			repo.config.PaginationSize = 2
+
+			for actorID, ts := range tt.likes {
+				require.NoError(t, repo.db.Create(&models.Decision{
+					ActorUserID:     actorID,
+					RecipientUserID: "alice",
+					Liked:           true,
+					UnixTimestamp:   ts,
+				}).Error)
+			}
+
+			var got []string
+			page := tt.filter
+			for {
+				likers, more, err := repo.GetLikers(ctx, "alice", page)
+				require.NoError(t, err)
+				for i := range likers {
+					got = append(got, likers[i].ActorId)
+				}
+				if !more {
+					break
+				}
+				last := &likers[len(likers)-1]
+				page.After = &pagination.Cursor{Timestamp: int64(last.UnixTimestamp), ID: last.ActorId, Direction: page.Direction}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDBRepository_GetLikers_PageSize(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	repo.config.PaginationSize = 2
+
+	for i, actorID := range []string{"user1", "user2", "user3", "user4", "user5", "user6"} {
+		require.NoError(t, repo.db.Create(&models.Decision{
+			ActorUserID:     actorID,
+			RecipientUserID: "alice",
+			Liked:           true,
+			UnixTimestamp:   int64(1000 + i),
+		}).Error)
+	}
+
+	// a cursor stays valid when the next page asks for a different size
+	var pages [][]string
+	page := pagination.Page{Direction: pagination.Ascending}
+	for _, size := range []int{1, 3, 0} {
+		page.Size = size
+		likers, _, err := repo.GetLikers(ctx, "alice", page)
+		require.NoError(t, err)
+
+		var ids []string
+		for i := range likers {
+			ids = append(ids, likers[i].ActorId)
+		}
+		pages = append(pages, ids)
+		last := &likers[len(likers)-1]
+		page.After = &pagination.Cursor{Timestamp: int64(last.UnixTimestamp), ID: last.ActorId, Direction: page.Direction}
+	}
+	assert.Equal(t, [][]string{{"user1"}, {"user2", "user3", "user4"}, {"user5", "user6"}}, pages)
+}
+
+func TestDBRepository_Matches(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	repo.config.PaginationSize = 2
+
+	for _, other := range []string{"bob", "carol", "dave"} {
+		_, err := repo.RecordDecision(ctx, "alice", other, true, "")
+		require.NoError(t, err)
+		outcome, err := repo.RecordDecision(ctx, other, "alice", true, "")
+		require.NoError(t, err)
+		require.True(t, outcome.Mutual)
+	}
+
+	// a pass removes the match from both sides
+	_, err := repo.RecordDecision(ctx, "carol", "alice", false, "")
+	require.NoError(t, err)
+
+	count, err := repo.CountMatches(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+
+	count, err = repo.CountMatches(ctx, "carol")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	matches, more, err := repo.ListMatches(ctx, "bob", nil)
+	require.NoError(t, err)
+	assert.False(t, more)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "alice", matches[0].UserId)
+
+	// walk alice's matches one page at a time
+	repo.config.PaginationSize = 1
+	var got []string
+	var after *pagination.Cursor
+	for {
+		matches, more, err := repo.ListMatches(ctx, "alice", after)
+		require.NoError(t, err)
+		for i := range matches {
+			got = append(got, matches[i].UserId)
+		}
+		if !more {
+			break
+		}
+		last := &matches[len(matches)-1]
+		after = &pagination.Cursor{Timestamp: int64(last.UnixTimestamp), ID: last.UserId}
+	}
+	assert.ElementsMatch(t, []string{"bob", "dave"}, got)
+}
+
+func TestDBRepository_Block(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	likerIDs := func(likers []Liker) []string {
+		var ids []string
+		for i := range likers {
+			ids = append(ids, likers[i].ActorId)
+		}
+		return ids
+	}
+	assertHidden := func(hidden bool) {
+		t.Helper()
+		visible, wantMatches := []string{"bob", "carol"}, uint64(1)
+		if hidden {
+			visible, wantMatches = []string{"carol"}, 0
+		}
+
+		likers, _, err := repo.GetLikers(ctx, "alice", pagination.Page{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, visible, likerIDs(likers))
+
+		count, err := repo.CountLikes(ctx, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(len(visible)), count)
+
+		likers, _, err = repo.GetNewLikers(ctx, "bob", pagination.Page{})
+		require.NoError(t, err)
+		assert.Empty(t, likers)
+
+		scanned, err := repo.ScanLikes(ctx, "alice", "", "", 10)
+		require.NoError(t, err)
+		assert.Len(t, scanned, len(visible))
+
+		matches, err := repo.CountMatches(ctx, "bob")
+		require.NoError(t, err)
+		assert.Equal(t, wantMatches, matches)
+	}
+
+	for _, d := range []struct{ actorID, recipientID string }{{"alice", "bob"}, {"bob", "alice"}, {"carol", "alice"}} {
+		_, err := repo.RecordDecision(ctx, d.actorID, d.recipientID, true, "")
+		require.NoError(t, err)
+	}
+	assertHidden(false)
+
+	blockOutcome, err := repo.Block(ctx, "alice", "bob", "spam")
+	require.NoError(t, err)
+	assertHidden(true)
+
+	// the block reports the likes it hid in both directions, not carol's
+	var hidden [][2]string
+	for _, d := range blockOutcome.Hidden {
+		hidden = append(hidden, [2]string{d.ActorUserID, d.RecipientUserID})
+	}
+	assert.Equal(t, [][2]string{{"alice", "bob"}, {"bob", "alice"}}, hidden)
+
+	// the block removes both users from each other's sorted sets
+	var events []models.OutboxEvent
+	require.NoError(t, repo.db.Order("id DESC").Limit(4).Find(&events).Error)
+	for _, e := range events {
+		assert.Contains(t, []string{models.OutboxRemoveLike, models.OutboxRemoveNewLike}, e.Op)
+	}
+
+	// decisions between blocked users are kept but change nothing
+	outcome, err := repo.RecordDecision(ctx, "bob", "alice", true, "")
+	require.NoError(t, err)
+	assert.Equal(t, Outcome{}, outcome)
+	assertHidden(true)
+
+	blocked, more, err := repo.ListBlocked(ctx, "alice", nil)
+	require.NoError(t, err)
+	assert.False(t, more)
+	require.Len(t, blocked, 1)
+	assert.Equal(t, "bob", blocked[0].UserId)
+
+	// a block in the other direction keeps them hidden after the first one is lifted
+	blockOutcome, err = repo.Block(ctx, "bob", "alice", "")
+	require.NoError(t, err)
+	assert.Empty(t, blockOutcome.Hidden, "the likes were already hidden")
+	require.NoError(t, repo.Unblock(ctx, "alice", "bob"))
+	assertHidden(true)
+
+	require.NoError(t, repo.Unblock(ctx, "bob", "alice"))
+	assertHidden(false)
+
+	blocked, _, err = repo.ListBlocked(ctx, "alice", nil)
+	require.NoError(t, err)
+	assert.Empty(t, blocked)
+}
+
+func TestDBRepository_RecordDecision_LikeTimestampMode(t *testing.T) {
+	tests := []struct {
+		mode             string
+		wantRepeatLikeTS int64
+	}{
+		{mode: config.LikeTimestampFirst, wantRepeatLikeTS: 100},
+		{mode: config.LikeTimestampBump, wantRepeatLikeTS: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			ctx := context.Background()
+			repo := newTestRepository(t)
+			repo.config.LikeTimestampMode = tt.mode
+
+			decide := func(ts int64, liked bool) Outcome {
+				t.Helper()
+				repo.now = func() time.Time { return time.Unix(ts, 0) }
+				outcome, err := repo.RecordDecision(ctx, "alice", "bob", liked, "")
+				require.NoError(t, err)
+				return outcome
+			}
+			likeTS := func() int64 {
+				t.Helper()
+				var d models.Decision
+				require.NoError(t, repo.db.Take(&d, "actor_user_id = ? AND recipient_user_id = ?", "alice", "bob").Error)
+				return d.UnixTimestamp
+			}
+
+			decide(100, true)
+			outcome := decide(200, true)
+			assert.Equal(t, tt.wantRepeatLikeTS, likeTS())
+			assert.Equal(t, tt.wantRepeatLikeTS, outcome.Timestamp, "the outcome reports the stored time")
+
+			var event models.OutboxEvent
+			require.NoError(t, repo.db.Where("op = ?", models.OutboxAddLike).Order("id DESC").Take(&event).Error)
+			assert.Equal(t, tt.wantRepeatLikeTS, event.UnixTimestamp, "the cache gets the same time")
+
+			// a like after a pass is a new like in both modes
+			decide(300, false)
+			decide(400, true)
+			assert.Equal(t, int64(400), likeTS())
+
+			// the history keeps the time of every decision
+			events, _, err := repo.GetDecisionHistory(ctx, "alice", "bob", nil)
+			require.NoError(t, err)
+			var times []int64
+			for _, e := range events {
+				times = append(times, e.UnixTimestamp)
+			}
+			assert.Equal(t, []int64{400, 300, 200, 100}, times)
+		})
+	}
+}
+
+func TestDBRepository_GetDecisionHistory(t *testing.T) {
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+	repo := newTestRepository(t)
+	repo.config.PaginationSize = 2
+
+	// a like -> pass -> like flip overwrites the decision, but not its history
+	for _, liked := range []bool{true, false, true} {
+		_, err := repo.RecordDecision(ctx, "alice", "bob", liked, "")
+		require.NoError(t, err)
+	}
+	_, err := repo.RecordDecision(ctx, "alice", "carol", false, "")
+	require.NoError(t, err)
+
+	var got []bool
+	var after *pagination.Cursor
+	for {
+		events, more, err := repo.GetDecisionHistory(ctx, "alice", "bob", after)
+		require.NoError(t, err)
+		for _, e := range events {
+			assert.Equal(t, "bob", e.RecipientUserID)
+			assert.Equal(t, models.DecisionSourceAPI, e.Source)
+			assert.Equal(t, "req-1", e.RequestID)
+			got = append(got, e.Liked)
+		}
+		if !more {
+			break
+		}
+		after = &pagination.Cursor{ID: strconv.FormatUint(events[len(events)-1].ID, 10)}
+	}
+	assert.Equal(t, []bool{true, false, true}, got, "newest first")
+
+	events, more, err := repo.GetDecisionHistory(ctx, "alice", "", nil)
+	require.NoError(t, err)
+	assert.True(t, more)
+	require.Len(t, events, 2)
+	assert.Equal(t, "carol", events[0].RecipientUserID)
+
+	_, _, err = repo.GetDecisionHistory(ctx, "alice", "", &pagination.Cursor{ID: "bob"})
+	assert.ErrorIs(t, err, pagination.ErrInvalidCursor)
+}
+
+func TestDBRepository_ScanLikes(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	decisions := []struct {
+		actorID, recipientID string
+		liked                bool
+	}{
+		{"bob", "alice", true},
+		{"carol", "alice", true},
+		{"dave", "alice", false},
+		{"alice", "carol", true},
+		{"alice", "bob", false},
+	}
+	for _, d := range decisions {
+		_, err := repo.RecordDecision(ctx, d.actorID, d.recipientID, d.liked, "")
+		require.NoError(t, err)
+	}
+
+	var got []ScannedLike
+	var last ScannedLike
+	for {
+		batch, err := repo.ScanLikes(ctx, "", last.RecipientUserID, last.ActorUserID, 2)
+		require.NoError(t, err)
+		got = append(got, batch...)
+		if len(batch) < 2 {
+			break
+		}
+		last = batch[len(batch)-1]
+	}
+
+	type like struct {
+		actorID, recipientID string
+		likedBack            bool
+	}
+	var likes []like
+	for _, l := range got {
+		likes = append(likes, like{l.ActorUserID, l.RecipientUserID, l.LikedBack})
+	}
+	assert.Equal(t, []like{
+		{"bob", "alice", false},
+		{"carol", "alice", true},
+		{"alice", "carol", true},
+	}, likes)
+
+	only, err := repo.ScanLikes(ctx, "carol", "", "", 10)
+	require.NoError(t, err)
+	require.Len(t, only, 1)
+	assert.Equal(t, "alice", only[0].ActorUserID)
+}
+
+func TestDBRepository_ProcessOutbox(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	// each like writes three events
+	for _, actorID := range []string{"user1", "user2"} {
+		_, err := repo.RecordDecision(ctx, actorID, "alice", true, "")
+		require.NoError(t, err)
+	}
+
+	// a failed apply keeps the batch pending and records the attempt
+	count, err := repo.ProcessOutbox(ctx, 4, func(events []models.OutboxEvent) error {
+		return errors.New("redis error")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 0, count)
+
+	var applied []uint64
+	apply := func(events []models.OutboxEvent) error {
+		for _, e := range events {
+			applied = append(applied, e.ID)
+		}
+		return nil
+	}
+
+	count, err = repo.ProcessOutbox(ctx, 4, apply)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	count, err = repo.ProcessOutbox(ctx, 4, apply)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = repo.ProcessOutbox(ctx, 4, apply)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	assert.IsIncreasing(t, applied)
+	assert.Len(t, applied, 6)
+
+	var first models.OutboxEvent
+	require.NoError(t, repo.db.Order("id ASC").First(&first).Error)
+	assert.Equal(t, 1, first.Attempts)
+	assert.Equal(t, "redis error", first.LastError)
+	assert.NotNil(t, first.ProcessedAt)
+}
+
+func TestDBRepository_ReplayOutbox(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	highWater, err := repo.OutboxHighWater(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, highWater)
+
+	_, err = repo.RecordDecision(ctx, "user1", "alice", true, "")
+	require.NoError(t, err)
+	highWater, err = repo.OutboxHighWater(ctx)
+	require.NoError(t, err)
+	assert.NotZero(t, highWater)
+
+	// events after the mark are replayed whether the relay already applied them or not
+	_, err = repo.RecordDecision(ctx, "user2", "alice", true, "")
+	require.NoError(t, err)
+	_, err = repo.RecordDecision(ctx, "user3", "bob", true, "")
+	require.NoError(t, err)
+	_, err = repo.ProcessOutbox(ctx, 100, func([]models.OutboxEvent) error { return nil })
+	require.NoError(t, err)
+
+	var replayed []models.OutboxEvent
+	err = repo.ReplayOutbox(ctx, "alice", highWater, func(events []models.OutboxEvent) error {
+		replayed = events
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, replayed)
+	for _, e := range replayed {
+		assert.Greater(t, e.ID, highWater)
+		assert.Equal(t, "alice", e.RecipientUserID)
+		assert.Equal(t, "user2", e.ActorUserID)
+	}
+
+	err = repo.ReplayOutbox(ctx, "alice", highWater, func([]models.OutboxEvent) error { return errors.New("redis error") })
+	assert.Error(t, err)
+
+	// with the lock free, trying replays the same events
+	var tried []models.OutboxEvent
+	err = repo.TryReplayOutbox(ctx, "alice", highWater, func(events []models.OutboxEvent) error {
+		tried = events
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, replayed, tried)
+}
+
+func TestDBRepository_TryReplayOutbox_Busy(t *testing.T) {
+	if os.Getenv("MUZZAPP_TEST_MYSQL_DSN") == "" {
+		t.Skip("sqlite has no row locks to skip, set MUZZAPP_TEST_MYSQL_DSN")
+	}
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	// the relay holds the lock while it applies a batch
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		require.NoError(t, lockRelay(tx))
+
+		err := repo.TryReplayOutbox(ctx, "alice", 0, func([]models.OutboxEvent) error {
+			t.Error("replayed while the relay holds the lock")
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrRelayBusy)
+		return nil
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/endyapina/muzzapp/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetDecision returns the decision of the actor about the recipient, or nil when there is
+// none. expired passes are returned until the sweeper archives them, the caller decides
+// whether they still count.
+func (r *DBRepository) GetDecision(ctx context.Context, actorID, recipientID string) (*models.Decision, error) {
+	var d models.Decision
+	err := r.db.WithContext(ctx).Where("actor_user_id = ? AND recipient_user_id = ?", actorID, recipientID).Take(&d).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ArchiveExpiredPasses moves up to limit passes made before the given unix timestamp from
+// decisions to expired_passes, oldest first, and returns how many were moved.
+//
+// a pass never reaches the cache and makes no match, so no outbox event is needed: the
+// recipient's like of the actor, if any, stays in their new likes either way. the history
+// in decision_events is kept, so undo and support tooling are unaffected.
+func (r *DBRepository) ArchiveExpiredPasses(ctx context.Context, before int64, limit int) (int, error) {
+	var archived int
+	err := r.withTx(ctx, func(tx *gorm.DB) error {
+		archived = 0
+		var batch []models.Decision
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("liked = ? AND unix_timestamp < ?", false, before).
+			Order("unix_timestamp ASC").
+			Limit(limit).
+			Find(&batch).Error; err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		now := r.now().Unix()
+		passes := make([]models.ExpiredPass, len(batch))
+		keys := make([][]any, len(batch))
+		for i, d := range batch {
+			passes[i] = models.ExpiredPass{
+				ActorUserID:     d.ActorUserID,
+				RecipientUserID: d.RecipientUserID,
+				UnixTimestamp:   d.UnixTimestamp,
+				ArchivedAt:      now,
+			}
+			keys[i] = []any{d.ActorUserID, d.RecipientUserID}
+		}
+		if err := tx.Create(passes).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("(actor_user_id, recipient_user_id) IN ?", keys).Delete(&models.Decision{}).Error; err != nil {
+			return err
+		}
+		archived = len(batch)
+		return nil
+	})
+	return archived, err
+}
+
+// ScanUserExpiredPasses returns up to limit archived passes the user made, or received when
+// received is set, in archive order and starting strictly after the pass with id afterID.
+// a pair can be archived more than once, so the passes are paged by id rather than by user.
+func (r *DBRepository) ScanUserExpiredPasses(ctx context.Context, userID string, received bool, afterID uint64, limit int) ([]models.ExpiredPass, error) {
+	userColumn := "actor_user_id"
+	if received {
+		userColumn = "recipient_user_id"
+	}
+
+	var passes []models.ExpiredPass
+	err := r.db.WithContext(ctx).
+		Where(userColumn+" = ? AND id > ?", userID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&passes).Error
+	return passes, err
+}
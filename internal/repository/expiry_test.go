@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/pagination"
+)
+
+func TestDBRepository_ArchiveExpiredPasses(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	var clock int64 = 1000
+	repo.now = func() time.Time { return time.Unix(clock, 0) }
+	for _, d := range []struct {
+		actorID, recipientID string
+		liked                bool
+	}{
+		{"alice", "bob", false},
+		{"alice", "carol", true},
+		{"bob", "alice", true},
+		{"carol", "alice", false},
+		{"dave", "alice", false},
+	} {
+		clock += 10
+		_, err := repo.RecordDecision(ctx, d.actorID, d.recipientID, d.liked, "")
+		require.NoError(t, err)
+	}
+
+	d, err := repo.GetDecision(ctx, "alice", "bob")
+	require.NoError(t, err)
+	require.NotNil(t, d)
+	assert.False(t, d.Liked)
+	assert.Equal(t, int64(1010), d.UnixTimestamp)
+
+	// the passes made before 1045, oldest first and a batch at a time; likes stay
+	clock = 2000
+	archived, err := repo.ArchiveExpiredPasses(ctx, 1045, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+	archived, err = repo.ArchiveExpiredPasses(ctx, 1045, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+	archived, err = repo.ArchiveExpiredPasses(ctx, 1045, 1)
+	require.NoError(t, err)
+	assert.Zero(t, archived)
+
+	d, err = repo.GetDecision(ctx, "alice", "bob")
+	require.NoError(t, err)
+	assert.Nil(t, d)
+	d, err = repo.GetDecision(ctx, "dave", "alice")
+	require.NoError(t, err)
+	assert.NotNil(t, d, "pass made after the cutoff")
+
+	var passes []models.ExpiredPass
+	require.NoError(t, repo.db.Order("id ASC").Find(&passes).Error)
+	require.Len(t, passes, 2)
+	assert.Equal(t, models.ExpiredPass{ID: passes[0].ID, ActorUserID: "alice", RecipientUserID: "bob", UnixTimestamp: 1010, ArchivedAt: 2000}, passes[0])
+	assert.Equal(t, models.ExpiredPass{ID: passes[1].ID, ActorUserID: "carol", RecipientUserID: "alice", UnixTimestamp: 1040, ArchivedAt: 2000}, passes[1])
+
+	// the export pages through them by id
+	made, err := repo.ScanUserExpiredPasses(ctx, "alice", false, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, passes[:1], made)
+	received, err := repo.ScanUserExpiredPasses(ctx, "alice", true, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, passes[1:], received)
+	received, err = repo.ScanUserExpiredPasses(ctx, "alice", true, passes[1].ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, received)
+
+	// the like of bob is still a new like of alice, and the history is kept
+	likers, _, err := repo.GetNewLikers(ctx, "alice", pagination.Page{})
+	require.NoError(t, err)
+	require.Len(t, likers, 1)
+	assert.Equal(t, "bob", likers[0].ActorId)
+	events, _, err := repo.GetDecisionHistory(ctx, "alice", "bob", nil)
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	// archived passes are user data too
+	repo.config.DeletionBatchSize = 10
+	report, err := repo.DeleteUserData(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), report.ExpiredPasses)
+	assert.Zero(t, report.Remaining)
+}
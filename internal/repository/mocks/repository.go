@@ -5,8 +5,14 @@ package mocks
 import (
 	context "context"
 
-	proto "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
+	models "github.com/endyapina/muzzapp/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	pagination "github.com/endyapina/muzzapp/internal/pagination"
+
+	proto "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
+
+	repository "github.com/endyapina/muzzapp/internal/repository"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -22,27 +28,27 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
-// CheckMutualLike provides a mock function with given fields: ctx, actorID, recipientID
-func (_m *Repository) CheckMutualLike(ctx context.Context, actorID string, recipientID string) (bool, error) {
-	ret := _m.Called(ctx, actorID, recipientID)
+// ArchiveExpiredPasses provides a mock function with given fields: ctx, before, limit
+func (_m *Repository) ArchiveExpiredPasses(ctx context.Context, before int64, limit int) (int, error) {
+	ret := _m.Called(ctx, before, limit)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CheckMutualLike")
+		panic("no return value specified for ArchiveExpiredPasses")
 	}
 
-	var r0 bool
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return rf(ctx, actorID, recipientID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) (int, error)); ok {
+		return rf(ctx, before, limit)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-		r0 = rf(ctx, actorID, recipientID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) int); ok {
+		r0 = rf(ctx, before, limit)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, actorID, recipientID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, before, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -50,32 +56,91 @@ func (_m *Repository) CheckMutualLike(ctx context.Context, actorID string, recip
 	return r0, r1
 }
 
-// Repository_CheckMutualLike_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckMutualLike'
-type Repository_CheckMutualLike_Call struct {
+// Repository_ArchiveExpiredPasses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveExpiredPasses'
+type Repository_ArchiveExpiredPasses_Call struct {
 	*mock.Call
 }
 
-// CheckMutualLike is a helper method to define mock.On call
+// ArchiveExpiredPasses is a helper method to define mock.On call
 //   - ctx context.Context
-//   - actorID string
-//   - recipientID string
-func (_e *Repository_Expecter) CheckMutualLike(ctx interface{}, actorID interface{}, recipientID interface{}) *Repository_CheckMutualLike_Call {
-	return &Repository_CheckMutualLike_Call{Call: _e.mock.On("CheckMutualLike", ctx, actorID, recipientID)}
+//   - before int64
+//   - limit int
+func (_e *Repository_Expecter) ArchiveExpiredPasses(ctx interface{}, before interface{}, limit interface{}) *Repository_ArchiveExpiredPasses_Call {
+	return &Repository_ArchiveExpiredPasses_Call{Call: _e.mock.On("ArchiveExpiredPasses", ctx, before, limit)}
 }
 
-func (_c *Repository_CheckMutualLike_Call) Run(run func(ctx context.Context, actorID string, recipientID string)) *Repository_CheckMutualLike_Call {
+func (_c *Repository_ArchiveExpiredPasses_Call) Run(run func(ctx context.Context, before int64, limit int)) *Repository_ArchiveExpiredPasses_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_ArchiveExpiredPasses_Call) Return(_a0 int, _a1 error) *Repository_ArchiveExpiredPasses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ArchiveExpiredPasses_Call) RunAndReturn(run func(context.Context, int64, int) (int, error)) *Repository_ArchiveExpiredPasses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Block provides a mock function with given fields: ctx, blockerID, blockedID, reason
+func (_m *Repository) Block(ctx context.Context, blockerID string, blockedID string, reason string) (repository.BlockOutcome, error) {
+	ret := _m.Called(ctx, blockerID, blockedID, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Block")
+	}
+
+	var r0 repository.BlockOutcome
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (repository.BlockOutcome, error)); ok {
+		return rf(ctx, blockerID, blockedID, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) repository.BlockOutcome); ok {
+		r0 = rf(ctx, blockerID, blockedID, reason)
+	} else {
+		r0 = ret.Get(0).(repository.BlockOutcome)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, blockerID, blockedID, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_Block_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Block'
+type Repository_Block_Call struct {
+	*mock.Call
+}
+
+// Block is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockerID string
+//   - blockedID string
+//   - reason string
+func (_e *Repository_Expecter) Block(ctx interface{}, blockerID interface{}, blockedID interface{}, reason interface{}) *Repository_Block_Call {
+	return &Repository_Block_Call{Call: _e.mock.On("Block", ctx, blockerID, blockedID, reason)}
+}
+
+func (_c *Repository_Block_Call) Run(run func(ctx context.Context, blockerID string, blockedID string, reason string)) *Repository_Block_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
 
-func (_c *Repository_CheckMutualLike_Call) Return(_a0 bool, _a1 error) *Repository_CheckMutualLike_Call {
+func (_c *Repository_Block_Call) Return(_a0 repository.BlockOutcome, _a1 error) *Repository_Block_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_CheckMutualLike_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *Repository_CheckMutualLike_Call {
+func (_c *Repository_Block_Call) RunAndReturn(run func(context.Context, string, string, string) (repository.BlockOutcome, error)) *Repository_Block_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -137,36 +202,278 @@ func (_c *Repository_CountLikes_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
-// GetLikers provides a mock function with given fields: ctx, recipientID, paginationToken
-func (_m *Repository) GetLikers(ctx context.Context, recipientID string, paginationToken string) ([]proto.ListLikedYouResponse_Liker, string, error) {
-	ret := _m.Called(ctx, recipientID, paginationToken)
+// CountMatches provides a mock function with given fields: ctx, userID
+func (_m *Repository) CountMatches(ctx context.Context, userID string) (uint64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountMatches")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (uint64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) uint64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CountMatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountMatches'
+type Repository_CountMatches_Call struct {
+	*mock.Call
+}
+
+// CountMatches is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) CountMatches(ctx interface{}, userID interface{}) *Repository_CountMatches_Call {
+	return &Repository_CountMatches_Call{Call: _e.mock.On("CountMatches", ctx, userID)}
+}
+
+func (_c *Repository_CountMatches_Call) Run(run func(ctx context.Context, userID string)) *Repository_CountMatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_CountMatches_Call) Return(_a0 uint64, _a1 error) *Repository_CountMatches_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CountMatches_Call) RunAndReturn(run func(context.Context, string) (uint64, error)) *Repository_CountMatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUserData provides a mock function with given fields: ctx, userID
+func (_m *Repository) DeleteUserData(ctx context.Context, userID string) (repository.DeletionReport, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUserData")
+	}
+
+	var r0 repository.DeletionReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (repository.DeletionReport, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) repository.DeletionReport); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(repository.DeletionReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_DeleteUserData_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUserData'
+type Repository_DeleteUserData_Call struct {
+	*mock.Call
+}
+
+// DeleteUserData is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) DeleteUserData(ctx interface{}, userID interface{}) *Repository_DeleteUserData_Call {
+	return &Repository_DeleteUserData_Call{Call: _e.mock.On("DeleteUserData", ctx, userID)}
+}
+
+func (_c *Repository_DeleteUserData_Call) Run(run func(ctx context.Context, userID string)) *Repository_DeleteUserData_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteUserData_Call) Return(_a0 repository.DeletionReport, _a1 error) *Repository_DeleteUserData_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_DeleteUserData_Call) RunAndReturn(run func(context.Context, string) (repository.DeletionReport, error)) *Repository_DeleteUserData_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDecision provides a mock function with given fields: ctx, actorID, recipientID
+func (_m *Repository) GetDecision(ctx context.Context, actorID string, recipientID string) (*models.Decision, error) {
+	ret := _m.Called(ctx, actorID, recipientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDecision")
+	}
+
+	var r0 *models.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Decision, error)); ok {
+		return rf(ctx, actorID, recipientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Decision); ok {
+		r0 = rf(ctx, actorID, recipientID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, actorID, recipientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_GetDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDecision'
+type Repository_GetDecision_Call struct {
+	*mock.Call
+}
+
+// GetDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID string
+//   - recipientID string
+func (_e *Repository_Expecter) GetDecision(ctx interface{}, actorID interface{}, recipientID interface{}) *Repository_GetDecision_Call {
+	return &Repository_GetDecision_Call{Call: _e.mock.On("GetDecision", ctx, actorID, recipientID)}
+}
+
+func (_c *Repository_GetDecision_Call) Run(run func(ctx context.Context, actorID string, recipientID string)) *Repository_GetDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_GetDecision_Call) Return(_a0 *models.Decision, _a1 error) *Repository_GetDecision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_GetDecision_Call) RunAndReturn(run func(context.Context, string, string) (*models.Decision, error)) *Repository_GetDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDecisionHistory provides a mock function with given fields: ctx, actorID, recipientID, after
+func (_m *Repository) GetDecisionHistory(ctx context.Context, actorID string, recipientID string, after *pagination.Cursor) ([]models.DecisionEvent, bool, error) {
+	ret := _m.Called(ctx, actorID, recipientID, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDecisionHistory")
+	}
+
+	var r0 []models.DecisionEvent
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *pagination.Cursor) ([]models.DecisionEvent, bool, error)); ok {
+		return rf(ctx, actorID, recipientID, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *pagination.Cursor) []models.DecisionEvent); ok {
+		r0 = rf(ctx, actorID, recipientID, after)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DecisionEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *pagination.Cursor) bool); ok {
+		r1 = rf(ctx, actorID, recipientID, after)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, *pagination.Cursor) error); ok {
+		r2 = rf(ctx, actorID, recipientID, after)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Repository_GetDecisionHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDecisionHistory'
+type Repository_GetDecisionHistory_Call struct {
+	*mock.Call
+}
+
+// GetDecisionHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID string
+//   - recipientID string
+//   - after *pagination.Cursor
+func (_e *Repository_Expecter) GetDecisionHistory(ctx interface{}, actorID interface{}, recipientID interface{}, after interface{}) *Repository_GetDecisionHistory_Call {
+	return &Repository_GetDecisionHistory_Call{Call: _e.mock.On("GetDecisionHistory", ctx, actorID, recipientID, after)}
+}
+
+func (_c *Repository_GetDecisionHistory_Call) Run(run func(ctx context.Context, actorID string, recipientID string, after *pagination.Cursor)) *Repository_GetDecisionHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*pagination.Cursor))
+	})
+	return _c
+}
+
+func (_c *Repository_GetDecisionHistory_Call) Return(_a0 []models.DecisionEvent, _a1 bool, _a2 error) *Repository_GetDecisionHistory_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Repository_GetDecisionHistory_Call) RunAndReturn(run func(context.Context, string, string, *pagination.Cursor) ([]models.DecisionEvent, bool, error)) *Repository_GetDecisionHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLikers provides a mock function with given fields: ctx, recipientID, page
+func (_m *Repository) GetLikers(ctx context.Context, recipientID string, page pagination.Page) ([]proto.ListLikedYouResponse_Liker, bool, error) {
+	ret := _m.Called(ctx, recipientID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLikers")
 	}
 
 	var r0 []proto.ListLikedYouResponse_Liker
-	var r1 string
+	var r1 bool
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]proto.ListLikedYouResponse_Liker, string, error)); ok {
-		return rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) ([]proto.ListLikedYouResponse_Liker, bool, error)); ok {
+		return rf(ctx, recipientID, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []proto.ListLikedYouResponse_Liker); ok {
-		r0 = rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) []proto.ListLikedYouResponse_Liker); ok {
+		r0 = rf(ctx, recipientID, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]proto.ListLikedYouResponse_Liker)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Page) bool); ok {
+		r1 = rf(ctx, recipientID, page)
 	} else {
-		r1 = ret.Get(1).(string)
+		r1 = ret.Get(1).(bool)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(2).(func(context.Context, string, pagination.Page) error); ok {
+		r2 = rf(ctx, recipientID, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -182,58 +489,58 @@ type Repository_GetLikers_Call struct {
 // GetLikers is a helper method to define mock.On call
 //   - ctx context.Context
 //   - recipientID string
-//   - paginationToken string
-func (_e *Repository_Expecter) GetLikers(ctx interface{}, recipientID interface{}, paginationToken interface{}) *Repository_GetLikers_Call {
-	return &Repository_GetLikers_Call{Call: _e.mock.On("GetLikers", ctx, recipientID, paginationToken)}
+//   - page pagination.Page
+func (_e *Repository_Expecter) GetLikers(ctx interface{}, recipientID interface{}, page interface{}) *Repository_GetLikers_Call {
+	return &Repository_GetLikers_Call{Call: _e.mock.On("GetLikers", ctx, recipientID, page)}
 }
 
-func (_c *Repository_GetLikers_Call) Run(run func(ctx context.Context, recipientID string, paginationToken string)) *Repository_GetLikers_Call {
+func (_c *Repository_GetLikers_Call) Run(run func(ctx context.Context, recipientID string, page pagination.Page)) *Repository_GetLikers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(pagination.Page))
 	})
 	return _c
 }
 
-func (_c *Repository_GetLikers_Call) Return(_a0 []proto.ListLikedYouResponse_Liker, _a1 string, _a2 error) *Repository_GetLikers_Call {
+func (_c *Repository_GetLikers_Call) Return(_a0 []proto.ListLikedYouResponse_Liker, _a1 bool, _a2 error) *Repository_GetLikers_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Repository_GetLikers_Call) RunAndReturn(run func(context.Context, string, string) ([]proto.ListLikedYouResponse_Liker, string, error)) *Repository_GetLikers_Call {
+func (_c *Repository_GetLikers_Call) RunAndReturn(run func(context.Context, string, pagination.Page) ([]proto.ListLikedYouResponse_Liker, bool, error)) *Repository_GetLikers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNewLikers provides a mock function with given fields: ctx, recipientID, paginationToken
-func (_m *Repository) GetNewLikers(ctx context.Context, recipientID string, paginationToken string) ([]proto.ListLikedYouResponse_Liker, string, error) {
-	ret := _m.Called(ctx, recipientID, paginationToken)
+// GetNewLikers provides a mock function with given fields: ctx, recipientID, page
+func (_m *Repository) GetNewLikers(ctx context.Context, recipientID string, page pagination.Page) ([]proto.ListLikedYouResponse_Liker, bool, error) {
+	ret := _m.Called(ctx, recipientID, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetNewLikers")
 	}
 
 	var r0 []proto.ListLikedYouResponse_Liker
-	var r1 string
+	var r1 bool
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]proto.ListLikedYouResponse_Liker, string, error)); ok {
-		return rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) ([]proto.ListLikedYouResponse_Liker, bool, error)); ok {
+		return rf(ctx, recipientID, page)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) []proto.ListLikedYouResponse_Liker); ok {
-		r0 = rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, pagination.Page) []proto.ListLikedYouResponse_Liker); ok {
+		r0 = rf(ctx, recipientID, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]proto.ListLikedYouResponse_Liker)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(1).(func(context.Context, string, pagination.Page) bool); ok {
+		r1 = rf(ctx, recipientID, page)
 	} else {
-		r1 = ret.Get(1).(string)
+		r1 = ret.Get(1).(bool)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = rf(ctx, recipientID, paginationToken)
+	if rf, ok := ret.Get(2).(func(context.Context, string, pagination.Page) error); ok {
+		r2 = rf(ctx, recipientID, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -249,131 +556,895 @@ type Repository_GetNewLikers_Call struct {
 // GetNewLikers is a helper method to define mock.On call
 //   - ctx context.Context
 //   - recipientID string
-//   - paginationToken string
-func (_e *Repository_Expecter) GetNewLikers(ctx interface{}, recipientID interface{}, paginationToken interface{}) *Repository_GetNewLikers_Call {
-	return &Repository_GetNewLikers_Call{Call: _e.mock.On("GetNewLikers", ctx, recipientID, paginationToken)}
+//   - page pagination.Page
+func (_e *Repository_Expecter) GetNewLikers(ctx interface{}, recipientID interface{}, page interface{}) *Repository_GetNewLikers_Call {
+	return &Repository_GetNewLikers_Call{Call: _e.mock.On("GetNewLikers", ctx, recipientID, page)}
 }
 
-func (_c *Repository_GetNewLikers_Call) Run(run func(ctx context.Context, recipientID string, paginationToken string)) *Repository_GetNewLikers_Call {
+func (_c *Repository_GetNewLikers_Call) Run(run func(ctx context.Context, recipientID string, page pagination.Page)) *Repository_GetNewLikers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(pagination.Page))
 	})
 	return _c
 }
 
-func (_c *Repository_GetNewLikers_Call) Return(_a0 []proto.ListLikedYouResponse_Liker, _a1 string, _a2 error) *Repository_GetNewLikers_Call {
+func (_c *Repository_GetNewLikers_Call) Return(_a0 []proto.ListLikedYouResponse_Liker, _a1 bool, _a2 error) *Repository_GetNewLikers_Call {
 	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Repository_GetNewLikers_Call) RunAndReturn(run func(context.Context, string, string) ([]proto.ListLikedYouResponse_Liker, string, error)) *Repository_GetNewLikers_Call {
+func (_c *Repository_GetNewLikers_Call) RunAndReturn(run func(context.Context, string, pagination.Page) ([]proto.ListLikedYouResponse_Liker, bool, error)) *Repository_GetNewLikers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// HasRecipientLikedActor provides a mock function with given fields: ctx, recipientID, actorID
-func (_m *Repository) HasRecipientLikedActor(ctx context.Context, recipientID string, actorID string) (bool, error) {
-	ret := _m.Called(ctx, recipientID, actorID)
+// ListBlocked provides a mock function with given fields: ctx, blockerID, after
+func (_m *Repository) ListBlocked(ctx context.Context, blockerID string, after *pagination.Cursor) ([]proto.ListBlockedResponse_Blocked, bool, error) {
+	ret := _m.Called(ctx, blockerID, after)
 
 	if len(ret) == 0 {
-		panic("no return value specified for HasRecipientLikedActor")
+		panic("no return value specified for ListBlocked")
 	}
 
-	var r0 bool
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
-		return rf(ctx, recipientID, actorID)
+	var r0 []proto.ListBlockedResponse_Blocked
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Cursor) ([]proto.ListBlockedResponse_Blocked, bool, error)); ok {
+		return rf(ctx, blockerID, after)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
-		r0 = rf(ctx, recipientID, actorID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Cursor) []proto.ListBlockedResponse_Blocked); ok {
+		r0 = rf(ctx, blockerID, after)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]proto.ListBlockedResponse_Blocked)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = rf(ctx, recipientID, actorID)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *pagination.Cursor) bool); ok {
+		r1 = rf(ctx, blockerID, after)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(bool)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, *pagination.Cursor) error); ok {
+		r2 = rf(ctx, blockerID, after)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Repository_HasRecipientLikedActor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasRecipientLikedActor'
-type Repository_HasRecipientLikedActor_Call struct {
+// Repository_ListBlocked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBlocked'
+type Repository_ListBlocked_Call struct {
 	*mock.Call
 }
 
-// HasRecipientLikedActor is a helper method to define mock.On call
+// ListBlocked is a helper method to define mock.On call
 //   - ctx context.Context
-//   - recipientID string
-//   - actorID string
-func (_e *Repository_Expecter) HasRecipientLikedActor(ctx interface{}, recipientID interface{}, actorID interface{}) *Repository_HasRecipientLikedActor_Call {
-	return &Repository_HasRecipientLikedActor_Call{Call: _e.mock.On("HasRecipientLikedActor", ctx, recipientID, actorID)}
+//   - blockerID string
+//   - after *pagination.Cursor
+func (_e *Repository_Expecter) ListBlocked(ctx interface{}, blockerID interface{}, after interface{}) *Repository_ListBlocked_Call {
+	return &Repository_ListBlocked_Call{Call: _e.mock.On("ListBlocked", ctx, blockerID, after)}
 }
 
-func (_c *Repository_HasRecipientLikedActor_Call) Run(run func(ctx context.Context, recipientID string, actorID string)) *Repository_HasRecipientLikedActor_Call {
+func (_c *Repository_ListBlocked_Call) Run(run func(ctx context.Context, blockerID string, after *pagination.Cursor)) *Repository_ListBlocked_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(*pagination.Cursor))
 	})
 	return _c
 }
 
-func (_c *Repository_HasRecipientLikedActor_Call) Return(_a0 bool, _a1 error) *Repository_HasRecipientLikedActor_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Repository_ListBlocked_Call) Return(_a0 []proto.ListBlockedResponse_Blocked, _a1 bool, _a2 error) *Repository_ListBlocked_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Repository_HasRecipientLikedActor_Call) RunAndReturn(run func(context.Context, string, string) (bool, error)) *Repository_HasRecipientLikedActor_Call {
+func (_c *Repository_ListBlocked_Call) RunAndReturn(run func(context.Context, string, *pagination.Cursor) ([]proto.ListBlockedResponse_Blocked, bool, error)) *Repository_ListBlocked_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpsertDecision provides a mock function with given fields: ctx, actorID, recipientID, liked
-func (_m *Repository) UpsertDecision(ctx context.Context, actorID string, recipientID string, liked bool) error {
-	ret := _m.Called(ctx, actorID, recipientID, liked)
+// ListMatches provides a mock function with given fields: ctx, userID, after
+func (_m *Repository) ListMatches(ctx context.Context, userID string, after *pagination.Cursor) ([]proto.ListMatchesResponse_Match, bool, error) {
+	ret := _m.Called(ctx, userID, after)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpsertDecision")
+		panic("no return value specified for ListMatches")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool) error); ok {
-		r0 = rf(ctx, actorID, recipientID, liked)
+	var r0 []proto.ListMatchesResponse_Match
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Cursor) ([]proto.ListMatchesResponse_Match, bool, error)); ok {
+		return rf(ctx, userID, after)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *pagination.Cursor) []proto.ListMatchesResponse_Match); ok {
+		r0 = rf(ctx, userID, after)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]proto.ListMatchesResponse_Match)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, *pagination.Cursor) bool); ok {
+		r1 = rf(ctx, userID, after)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, *pagination.Cursor) error); ok {
+		r2 = rf(ctx, userID, after)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Repository_UpsertDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertDecision'
-type Repository_UpsertDecision_Call struct {
+// Repository_ListMatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMatches'
+type Repository_ListMatches_Call struct {
 	*mock.Call
 }
 
-// UpsertDecision is a helper method to define mock.On call
+// ListMatches is a helper method to define mock.On call
 //   - ctx context.Context
-//   - actorID string
-//   - recipientID string
-//   - liked bool
-func (_e *Repository_Expecter) UpsertDecision(ctx interface{}, actorID interface{}, recipientID interface{}, liked interface{}) *Repository_UpsertDecision_Call {
-	return &Repository_UpsertDecision_Call{Call: _e.mock.On("UpsertDecision", ctx, actorID, recipientID, liked)}
+//   - userID string
+//   - after *pagination.Cursor
+func (_e *Repository_Expecter) ListMatches(ctx interface{}, userID interface{}, after interface{}) *Repository_ListMatches_Call {
+	return &Repository_ListMatches_Call{Call: _e.mock.On("ListMatches", ctx, userID, after)}
 }
 
-func (_c *Repository_UpsertDecision_Call) Run(run func(ctx context.Context, actorID string, recipientID string, liked bool)) *Repository_UpsertDecision_Call {
+func (_c *Repository_ListMatches_Call) Run(run func(ctx context.Context, userID string, after *pagination.Cursor)) *Repository_ListMatches_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool))
+		run(args[0].(context.Context), args[1].(string), args[2].(*pagination.Cursor))
 	})
 	return _c
 }
 
-func (_c *Repository_UpsertDecision_Call) Return(_a0 error) *Repository_UpsertDecision_Call {
-	_c.Call.Return(_a0)
+func (_c *Repository_ListMatches_Call) Return(_a0 []proto.ListMatchesResponse_Match, _a1 bool, _a2 error) *Repository_ListMatches_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Repository_ListMatches_Call) RunAndReturn(run func(context.Context, string, *pagination.Cursor) ([]proto.ListMatchesResponse_Match, bool, error)) *Repository_ListMatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OutboxHighWater provides a mock function with given fields: ctx
+func (_m *Repository) OutboxHighWater(ctx context.Context) (uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OutboxHighWater")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_OutboxHighWater_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OutboxHighWater'
+type Repository_OutboxHighWater_Call struct {
+	*mock.Call
+}
+
+// OutboxHighWater is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Repository_Expecter) OutboxHighWater(ctx interface{}) *Repository_OutboxHighWater_Call {
+	return &Repository_OutboxHighWater_Call{Call: _e.mock.On("OutboxHighWater", ctx)}
+}
+
+func (_c *Repository_OutboxHighWater_Call) Run(run func(ctx context.Context)) *Repository_OutboxHighWater_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Repository_OutboxHighWater_Call) Return(_a0 uint64, _a1 error) *Repository_OutboxHighWater_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_OutboxHighWater_Call) RunAndReturn(run func(context.Context) (uint64, error)) *Repository_OutboxHighWater_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProcessOutbox provides a mock function with given fields: ctx, limit, apply
+func (_m *Repository) ProcessOutbox(ctx context.Context, limit int, apply func([]models.OutboxEvent) error) (int, error) {
+	ret := _m.Called(ctx, limit, apply)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessOutbox")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, func([]models.OutboxEvent) error) (int, error)); ok {
+		return rf(ctx, limit, apply)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, func([]models.OutboxEvent) error) int); ok {
+		r0 = rf(ctx, limit, apply)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, func([]models.OutboxEvent) error) error); ok {
+		r1 = rf(ctx, limit, apply)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ProcessOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessOutbox'
+type Repository_ProcessOutbox_Call struct {
+	*mock.Call
+}
+
+// ProcessOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - apply func([]models.OutboxEvent) error
+func (_e *Repository_Expecter) ProcessOutbox(ctx interface{}, limit interface{}, apply interface{}) *Repository_ProcessOutbox_Call {
+	return &Repository_ProcessOutbox_Call{Call: _e.mock.On("ProcessOutbox", ctx, limit, apply)}
+}
+
+func (_c *Repository_ProcessOutbox_Call) Run(run func(ctx context.Context, limit int, apply func([]models.OutboxEvent) error)) *Repository_ProcessOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(func([]models.OutboxEvent) error))
+	})
+	return _c
+}
+
+func (_c *Repository_ProcessOutbox_Call) Return(_a0 int, _a1 error) *Repository_ProcessOutbox_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ProcessOutbox_Call) RunAndReturn(run func(context.Context, int, func([]models.OutboxEvent) error) (int, error)) *Repository_ProcessOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeIdempotencyKeys provides a mock function with given fields: ctx, before
+func (_m *Repository) PurgeIdempotencyKeys(ctx context.Context, before int64) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeIdempotencyKeys")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_PurgeIdempotencyKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeIdempotencyKeys'
+type Repository_PurgeIdempotencyKeys_Call struct {
+	*mock.Call
+}
+
+// PurgeIdempotencyKeys is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before int64
+func (_e *Repository_Expecter) PurgeIdempotencyKeys(ctx interface{}, before interface{}) *Repository_PurgeIdempotencyKeys_Call {
+	return &Repository_PurgeIdempotencyKeys_Call{Call: _e.mock.On("PurgeIdempotencyKeys", ctx, before)}
+}
+
+func (_c *Repository_PurgeIdempotencyKeys_Call) Run(run func(ctx context.Context, before int64)) *Repository_PurgeIdempotencyKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_PurgeIdempotencyKeys_Call) Return(_a0 int64, _a1 error) *Repository_PurgeIdempotencyKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_PurgeIdempotencyKeys_Call) RunAndReturn(run func(context.Context, int64) (int64, error)) *Repository_PurgeIdempotencyKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeOutbox provides a mock function with given fields: ctx, before
+func (_m *Repository) PurgeOutbox(ctx context.Context, before int64) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeOutbox")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_PurgeOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeOutbox'
+type Repository_PurgeOutbox_Call struct {
+	*mock.Call
+}
+
+// PurgeOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before int64
+func (_e *Repository_Expecter) PurgeOutbox(ctx interface{}, before interface{}) *Repository_PurgeOutbox_Call {
+	return &Repository_PurgeOutbox_Call{Call: _e.mock.On("PurgeOutbox", ctx, before)}
+}
+
+func (_c *Repository_PurgeOutbox_Call) Run(run func(ctx context.Context, before int64)) *Repository_PurgeOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_PurgeOutbox_Call) Return(_a0 int64, _a1 error) *Repository_PurgeOutbox_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_PurgeOutbox_Call) RunAndReturn(run func(context.Context, int64) (int64, error)) *Repository_PurgeOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordDecision provides a mock function with given fields: ctx, actorID, recipientID, liked, idempotencyKey
+func (_m *Repository) RecordDecision(ctx context.Context, actorID string, recipientID string, liked bool, idempotencyKey string) (repository.Outcome, error) {
+	ret := _m.Called(ctx, actorID, recipientID, liked, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDecision")
+	}
+
+	var r0 repository.Outcome
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, string) (repository.Outcome, error)); ok {
+		return rf(ctx, actorID, recipientID, liked, idempotencyKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, string) repository.Outcome); ok {
+		r0 = rf(ctx, actorID, recipientID, liked, idempotencyKey)
+	} else {
+		r0 = ret.Get(0).(repository.Outcome)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool, string) error); ok {
+		r1 = rf(ctx, actorID, recipientID, liked, idempotencyKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_RecordDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDecision'
+type Repository_RecordDecision_Call struct {
+	*mock.Call
+}
+
+// RecordDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID string
+//   - recipientID string
+//   - liked bool
+//   - idempotencyKey string
+func (_e *Repository_Expecter) RecordDecision(ctx interface{}, actorID interface{}, recipientID interface{}, liked interface{}, idempotencyKey interface{}) *Repository_RecordDecision_Call {
+	return &Repository_RecordDecision_Call{Call: _e.mock.On("RecordDecision", ctx, actorID, recipientID, liked, idempotencyKey)}
+}
+
+func (_c *Repository_RecordDecision_Call) Run(run func(ctx context.Context, actorID string, recipientID string, liked bool, idempotencyKey string)) *Repository_RecordDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_RecordDecision_Call) Return(_a0 repository.Outcome, _a1 error) *Repository_RecordDecision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_RecordDecision_Call) RunAndReturn(run func(context.Context, string, string, bool, string) (repository.Outcome, error)) *Repository_RecordDecision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordDecisions provides a mock function with given fields: ctx, actorID, decisions
+func (_m *Repository) RecordDecisions(ctx context.Context, actorID string, decisions []repository.BatchDecision) ([]repository.Outcome, error) {
+	ret := _m.Called(ctx, actorID, decisions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDecisions")
+	}
+
+	var r0 []repository.Outcome
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []repository.BatchDecision) ([]repository.Outcome, error)); ok {
+		return rf(ctx, actorID, decisions)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []repository.BatchDecision) []repository.Outcome); ok {
+		r0 = rf(ctx, actorID, decisions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.Outcome)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []repository.BatchDecision) error); ok {
+		r1 = rf(ctx, actorID, decisions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_RecordDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDecisions'
+type Repository_RecordDecisions_Call struct {
+	*mock.Call
+}
+
+// RecordDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID string
+//   - decisions []repository.BatchDecision
+func (_e *Repository_Expecter) RecordDecisions(ctx interface{}, actorID interface{}, decisions interface{}) *Repository_RecordDecisions_Call {
+	return &Repository_RecordDecisions_Call{Call: _e.mock.On("RecordDecisions", ctx, actorID, decisions)}
+}
+
+func (_c *Repository_RecordDecisions_Call) Run(run func(ctx context.Context, actorID string, decisions []repository.BatchDecision)) *Repository_RecordDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].([]repository.BatchDecision))
+	})
+	return _c
+}
+
+func (_c *Repository_RecordDecisions_Call) Return(_a0 []repository.Outcome, _a1 error) *Repository_RecordDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_RecordDecisions_Call) RunAndReturn(run func(context.Context, string, []repository.BatchDecision) ([]repository.Outcome, error)) *Repository_RecordDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplayOutbox provides a mock function with given fields: ctx, recipientID, afterID, apply
+func (_m *Repository) ReplayOutbox(ctx context.Context, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error) error {
+	ret := _m.Called(ctx, recipientID, afterID, apply)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayOutbox")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, func([]models.OutboxEvent) error) error); ok {
+		r0 = rf(ctx, recipientID, afterID, apply)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_ReplayOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayOutbox'
+type Repository_ReplayOutbox_Call struct {
+	*mock.Call
+}
+
+// ReplayOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientID string
+//   - afterID uint64
+//   - apply func([]models.OutboxEvent) error
+func (_e *Repository_Expecter) ReplayOutbox(ctx interface{}, recipientID interface{}, afterID interface{}, apply interface{}) *Repository_ReplayOutbox_Call {
+	return &Repository_ReplayOutbox_Call{Call: _e.mock.On("ReplayOutbox", ctx, recipientID, afterID, apply)}
+}
+
+func (_c *Repository_ReplayOutbox_Call) Run(run func(ctx context.Context, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error)) *Repository_ReplayOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(func([]models.OutboxEvent) error))
+	})
+	return _c
+}
+
+func (_c *Repository_ReplayOutbox_Call) Return(_a0 error) *Repository_ReplayOutbox_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_ReplayOutbox_Call) RunAndReturn(run func(context.Context, string, uint64, func([]models.OutboxEvent) error) error) *Repository_ReplayOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScanLikes provides a mock function with given fields: ctx, recipientID, afterRecipientID, afterActorID, limit
+func (_m *Repository) ScanLikes(ctx context.Context, recipientID string, afterRecipientID string, afterActorID string, limit int) ([]repository.ScannedLike, error) {
+	ret := _m.Called(ctx, recipientID, afterRecipientID, afterActorID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanLikes")
+	}
+
+	var r0 []repository.ScannedLike
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) ([]repository.ScannedLike, error)); ok {
+		return rf(ctx, recipientID, afterRecipientID, afterActorID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int) []repository.ScannedLike); ok {
+		r0 = rf(ctx, recipientID, afterRecipientID, afterActorID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.ScannedLike)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int) error); ok {
+		r1 = rf(ctx, recipientID, afterRecipientID, afterActorID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ScanLikes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScanLikes'
+type Repository_ScanLikes_Call struct {
+	*mock.Call
+}
+
+// ScanLikes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientID string
+//   - afterRecipientID string
+//   - afterActorID string
+//   - limit int
+func (_e *Repository_Expecter) ScanLikes(ctx interface{}, recipientID interface{}, afterRecipientID interface{}, afterActorID interface{}, limit interface{}) *Repository_ScanLikes_Call {
+	return &Repository_ScanLikes_Call{Call: _e.mock.On("ScanLikes", ctx, recipientID, afterRecipientID, afterActorID, limit)}
+}
+
+func (_c *Repository_ScanLikes_Call) Run(run func(ctx context.Context, recipientID string, afterRecipientID string, afterActorID string, limit int)) *Repository_ScanLikes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_ScanLikes_Call) Return(_a0 []repository.ScannedLike, _a1 error) *Repository_ScanLikes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ScanLikes_Call) RunAndReturn(run func(context.Context, string, string, string, int) ([]repository.ScannedLike, error)) *Repository_ScanLikes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScanUserDecisions provides a mock function with given fields: ctx, userID, received, afterUserID, limit
+func (_m *Repository) ScanUserDecisions(ctx context.Context, userID string, received bool, afterUserID string, limit int) ([]models.Decision, error) {
+	ret := _m.Called(ctx, userID, received, afterUserID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanUserDecisions")
+	}
+
+	var r0 []models.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, string, int) ([]models.Decision, error)); ok {
+		return rf(ctx, userID, received, afterUserID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, string, int) []models.Decision); ok {
+		r0 = rf(ctx, userID, received, afterUserID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, string, int) error); ok {
+		r1 = rf(ctx, userID, received, afterUserID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ScanUserDecisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScanUserDecisions'
+type Repository_ScanUserDecisions_Call struct {
+	*mock.Call
+}
+
+// ScanUserDecisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - received bool
+//   - afterUserID string
+//   - limit int
+func (_e *Repository_Expecter) ScanUserDecisions(ctx interface{}, userID interface{}, received interface{}, afterUserID interface{}, limit interface{}) *Repository_ScanUserDecisions_Call {
+	return &Repository_ScanUserDecisions_Call{Call: _e.mock.On("ScanUserDecisions", ctx, userID, received, afterUserID, limit)}
+}
+
+func (_c *Repository_ScanUserDecisions_Call) Run(run func(ctx context.Context, userID string, received bool, afterUserID string, limit int)) *Repository_ScanUserDecisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(string), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_ScanUserDecisions_Call) Return(_a0 []models.Decision, _a1 error) *Repository_ScanUserDecisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ScanUserDecisions_Call) RunAndReturn(run func(context.Context, string, bool, string, int) ([]models.Decision, error)) *Repository_ScanUserDecisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScanUserExpiredPasses provides a mock function with given fields: ctx, userID, received, afterID, limit
+func (_m *Repository) ScanUserExpiredPasses(ctx context.Context, userID string, received bool, afterID uint64, limit int) ([]models.ExpiredPass, error) {
+	ret := _m.Called(ctx, userID, received, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanUserExpiredPasses")
+	}
+
+	var r0 []models.ExpiredPass
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, uint64, int) ([]models.ExpiredPass, error)); ok {
+		return rf(ctx, userID, received, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, uint64, int) []models.ExpiredPass); ok {
+		r0 = rf(ctx, userID, received, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ExpiredPass)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, uint64, int) error); ok {
+		r1 = rf(ctx, userID, received, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ScanUserExpiredPasses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScanUserExpiredPasses'
+type Repository_ScanUserExpiredPasses_Call struct {
+	*mock.Call
+}
+
+// ScanUserExpiredPasses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - received bool
+//   - afterID uint64
+//   - limit int
+func (_e *Repository_Expecter) ScanUserExpiredPasses(ctx interface{}, userID interface{}, received interface{}, afterID interface{}, limit interface{}) *Repository_ScanUserExpiredPasses_Call {
+	return &Repository_ScanUserExpiredPasses_Call{Call: _e.mock.On("ScanUserExpiredPasses", ctx, userID, received, afterID, limit)}
+}
+
+func (_c *Repository_ScanUserExpiredPasses_Call) Run(run func(ctx context.Context, userID string, received bool, afterID uint64, limit int)) *Repository_ScanUserExpiredPasses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(uint64), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_ScanUserExpiredPasses_Call) Return(_a0 []models.ExpiredPass, _a1 error) *Repository_ScanUserExpiredPasses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ScanUserExpiredPasses_Call) RunAndReturn(run func(context.Context, string, bool, uint64, int) ([]models.ExpiredPass, error)) *Repository_ScanUserExpiredPasses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TryReplayOutbox provides a mock function with given fields: ctx, recipientID, afterID, apply
+func (_m *Repository) TryReplayOutbox(ctx context.Context, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error) error {
+	ret := _m.Called(ctx, recipientID, afterID, apply)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TryReplayOutbox")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint64, func([]models.OutboxEvent) error) error); ok {
+		r0 = rf(ctx, recipientID, afterID, apply)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_TryReplayOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TryReplayOutbox'
+type Repository_TryReplayOutbox_Call struct {
+	*mock.Call
+}
+
+// TryReplayOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipientID string
+//   - afterID uint64
+//   - apply func([]models.OutboxEvent) error
+func (_e *Repository_Expecter) TryReplayOutbox(ctx interface{}, recipientID interface{}, afterID interface{}, apply interface{}) *Repository_TryReplayOutbox_Call {
+	return &Repository_TryReplayOutbox_Call{Call: _e.mock.On("TryReplayOutbox", ctx, recipientID, afterID, apply)}
+}
+
+func (_c *Repository_TryReplayOutbox_Call) Run(run func(ctx context.Context, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error)) *Repository_TryReplayOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uint64), args[3].(func([]models.OutboxEvent) error))
+	})
+	return _c
+}
+
+func (_c *Repository_TryReplayOutbox_Call) Return(_a0 error) *Repository_TryReplayOutbox_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_TryReplayOutbox_Call) RunAndReturn(run func(context.Context, string, uint64, func([]models.OutboxEvent) error) error) *Repository_TryReplayOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unblock provides a mock function with given fields: ctx, blockerID, blockedID
+func (_m *Repository) Unblock(ctx context.Context, blockerID string, blockedID string) error {
+	ret := _m.Called(ctx, blockerID, blockedID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unblock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, blockerID, blockedID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_Unblock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unblock'
+type Repository_Unblock_Call struct {
+	*mock.Call
+}
+
+// Unblock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - blockerID string
+//   - blockedID string
+func (_e *Repository_Expecter) Unblock(ctx interface{}, blockerID interface{}, blockedID interface{}) *Repository_Unblock_Call {
+	return &Repository_Unblock_Call{Call: _e.mock.On("Unblock", ctx, blockerID, blockedID)}
+}
+
+func (_c *Repository_Unblock_Call) Run(run func(ctx context.Context, blockerID string, blockedID string)) *Repository_Unblock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_Unblock_Call) Return(_a0 error) *Repository_Unblock_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_Unblock_Call) RunAndReturn(run func(context.Context, string, string) error) *Repository_Unblock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UndoDecision provides a mock function with given fields: ctx, actorID
+func (_m *Repository) UndoDecision(ctx context.Context, actorID string) (repository.Undo, error) {
+	ret := _m.Called(ctx, actorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndoDecision")
+	}
+
+	var r0 repository.Undo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (repository.Undo, error)); ok {
+		return rf(ctx, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) repository.Undo); ok {
+		r0 = rf(ctx, actorID)
+	} else {
+		r0 = ret.Get(0).(repository.Undo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UndoDecision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndoDecision'
+type Repository_UndoDecision_Call struct {
+	*mock.Call
+}
+
+// UndoDecision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - actorID string
+func (_e *Repository_Expecter) UndoDecision(ctx interface{}, actorID interface{}) *Repository_UndoDecision_Call {
+	return &Repository_UndoDecision_Call{Call: _e.mock.On("UndoDecision", ctx, actorID)}
+}
+
+func (_c *Repository_UndoDecision_Call) Run(run func(ctx context.Context, actorID string)) *Repository_UndoDecision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_UndoDecision_Call) Return(_a0 repository.Undo, _a1 error) *Repository_UndoDecision_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_UpsertDecision_Call) RunAndReturn(run func(context.Context, string, string, bool) error) *Repository_UpsertDecision_Call {
+func (_c *Repository_UndoDecision_Call) RunAndReturn(run func(context.Context, string) (repository.Undo, error)) *Repository_UndoDecision_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,15 +2,36 @@ package repository
 
 import (
 	"context"
+
+	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/pagination"
 )
 
 // This interface allows us to mock the mysql db repository in unit tests
 // without depending on a real database.
 type Repository interface {
-	UpsertDecision(ctx context.Context, actorID, recipientID string, liked bool) error
-	CheckMutualLike(ctx context.Context, actorID, recipientID string) (bool, error)
-	GetLikers(ctx context.Context, recipientID string, paginationToken string) ([]Liker, string, error)
+	RecordDecision(ctx context.Context, actorID, recipientID string, liked bool, idempotencyKey string) (Outcome, error)
+	RecordDecisions(ctx context.Context, actorID string, decisions []BatchDecision) ([]Outcome, error)
+	UndoDecision(ctx context.Context, actorID string) (Undo, error)
+	GetDecision(ctx context.Context, actorID, recipientID string) (*models.Decision, error)
+	ArchiveExpiredPasses(ctx context.Context, before int64, limit int) (int, error)
+	GetLikers(ctx context.Context, recipientID string, page pagination.Page) ([]Liker, bool, error)
 	CountLikes(ctx context.Context, recipientID string) (uint64, error)
-	GetNewLikers(ctx context.Context, recipientID string, paginationToken string) ([]Liker, string, error)
-	HasRecipientLikedActor(ctx context.Context, recipientID, actorID string) (bool, error)
+	GetNewLikers(ctx context.Context, recipientID string, page pagination.Page) ([]Liker, bool, error)
+	ListMatches(ctx context.Context, userID string, after *pagination.Cursor) ([]Match, bool, error)
+	CountMatches(ctx context.Context, userID string) (uint64, error)
+	GetDecisionHistory(ctx context.Context, actorID, recipientID string, after *pagination.Cursor) ([]models.DecisionEvent, bool, error)
+	ScanLikes(ctx context.Context, recipientID, afterRecipientID, afterActorID string, limit int) ([]ScannedLike, error)
+	ProcessOutbox(ctx context.Context, limit int, apply func([]models.OutboxEvent) error) (int, error)
+	OutboxHighWater(ctx context.Context) (uint64, error)
+	ReplayOutbox(ctx context.Context, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error) error
+	TryReplayOutbox(ctx context.Context, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error) error
+	PurgeOutbox(ctx context.Context, before int64) (int64, error)
+	PurgeIdempotencyKeys(ctx context.Context, before int64) (int64, error)
+	Block(ctx context.Context, blockerID, blockedID, reason string) (BlockOutcome, error)
+	Unblock(ctx context.Context, blockerID, blockedID string) error
+	ListBlocked(ctx context.Context, blockerID string, after *pagination.Cursor) ([]Blocked, bool, error)
+	DeleteUserData(ctx context.Context, userID string) (DeletionReport, error)
+	ScanUserDecisions(ctx context.Context, userID string, received bool, afterUserID string, limit int) ([]models.Decision, error)
+	ScanUserExpiredPasses(ctx context.Context, userID string, received bool, afterID uint64, limit int) ([]models.ExpiredPass, error)
 }
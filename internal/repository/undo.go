@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/logging"
+	"github.com/endyapina/muzzapp/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrNothingToUndo is returned when the actor has no decision to undo, or already
+	// undid their last one.
+	ErrNothingToUndo = errors.New("no decision to undo")
+
+	// ErrUndoExpired is returned when the last decision of the actor is older than the
+	// undo window.
+	ErrUndoExpired = errors.New("decision is too old to be undone")
+)
+
+// Undo is what UndoDecision reverted.
+type Undo struct {
+	// Undone is the reverted decision
+	Undone models.DecisionEvent
+	// Restored is the decision in force again, nil when the actor had never decided about
+	// the recipient before
+	Restored *models.Decision
+	Outcome
+}
+
+// UndoDecision reverts the most recent decision of an actor, if it was made within
+// UndoWindow, and restores the pair as it was before: the previous decision is back in
+// the decisions table and the cache, or the row is gone if there was none or it was a
+// pass that expired meanwhile, and a match the decision made is revoked (one it broke
+// comes back).
+//
+// the undo is appended to the history as an event of its own, so only the last decision
+// can be undone and undoing twice fails with ErrNothingToUndo.
+func (r *DBRepository) UndoDecision(ctx context.Context, actorID string) (Undo, error) {
+	var undo Undo
+	err := r.withTx(ctx, func(tx *gorm.DB) error {
+		undo = Undo{}
+		now := r.now().Unix()
+
+		// locked so two undos of the actor take turns, the second one then finds the undo
+		// event of the first and fails instead of undoing the decision before
+		var last models.DecisionEvent
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("actor_user_id = ?", actorID).
+			Order("id DESC").
+			Take(&last).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return ErrNothingToUndo
+		case err != nil:
+			return err
+		case last.Source == models.DecisionSourceUndo:
+			return ErrNothingToUndo
+		case now-last.UnixTimestamp > int64(r.config.UndoWindow.Seconds()):
+			return ErrUndoExpired
+		}
+		undo.Undone = last
+
+		var history []models.DecisionEvent
+		if err := tx.Where("actor_user_id = ? AND recipient_user_id = ? AND id < ?", actorID, last.RecipientUserID, last.ID).
+			Order("id ASC").
+			Find(&history).Error; err != nil {
+			return err
+		}
+		undo.Restored = r.replayHistory(history)
+		if r.passExpired(undo.Restored, now) {
+			// the sweeper would archive it right away, the pair is undecided again instead
+			undo.Restored = nil
+		}
+
+		// the undo event records the decision in force again, a pass when there is none
+		liked := undo.Restored != nil && undo.Restored.Liked
+		if err := tx.Create(&models.DecisionEvent{
+			ActorUserID:     actorID,
+			RecipientUserID: last.RecipientUserID,
+			Liked:           liked,
+			Source:          models.DecisionSourceUndo,
+			RequestID:       logging.RequestID(ctx),
+			UnixTimestamp:   now,
+		}).Error; err != nil {
+			return err
+		}
+
+		ts := now
+		if undo.Restored != nil {
+			ts = undo.Restored.UnixTimestamp
+			err = tx.Save(undo.Restored).Error
+		} else {
+			err = tx.Where("actor_user_id = ? AND recipient_user_id = ?", actorID, last.RecipientUserID).Delete(&models.Decision{}).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		mutual, blocked, err := syncPair(tx, actorID, last.RecipientUserID, liked, ts)
+		if err != nil {
+			return err
+		}
+		undo.Outcome = Outcome{Mutual: mutual, Changed: !blocked && liked != last.Liked, Timestamp: ts}
+		return nil
+	})
+	if err != nil {
+		return Undo{}, err
+	}
+	return undo, nil
+}
+
+// passExpired reports whether d is a pass older than PassExpiry at now, see
+// ExploreService.GetDecision.
+func (r *DBRepository) passExpired(d *models.Decision, now int64) bool {
+	return d != nil && !d.Liked && r.config.PassExpiry > 0 && now-d.UnixTimestamp >= int64(r.config.PassExpiry.Seconds())
+}
+
+// replayHistory returns the decision of a pair after its history of events, oldest first,
+// or nil when the history is empty or every decision of it was undone. an undo event
+// cancels the event before it. likes keep their time the way RecordDecision keeps it in
+// the configured LikeTimestampMode.
+func (r *DBRepository) replayHistory(history []models.DecisionEvent) *models.Decision {
+	var stack []models.DecisionEvent
+	for _, event := range history {
+		if event.Source == models.DecisionSourceUndo {
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		}
+		stack = append(stack, event)
+	}
+	if len(stack) == 0 {
+		return nil
+	}
+
+	top := stack[len(stack)-1]
+	ts := top.UnixTimestamp
+	if top.Liked && r.config.LikeTimestampMode != config.LikeTimestampBump {
+		// the first like of the run of likes at the top of the history
+		for i := len(stack) - 1; i >= 0 && stack[i].Liked; i-- {
+			ts = stack[i].UnixTimestamp
+		}
+	}
+	return &models.Decision{
+		ActorUserID:     top.ActorUserID,
+		RecipientUserID: top.RecipientUserID,
+		Liked:           top.Liked,
+		UnixTimestamp:   ts,
+	}
+}
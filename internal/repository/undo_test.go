@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/pagination"
+)
+
+func TestDBRepository_UndoDecision(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	repo.config.UndoWindow = time.Minute
+
+	var clock int64 = 1000
+	repo.now = func() time.Time { return time.Unix(clock, 0) }
+	decide := func(actorID, recipientID string, liked bool) {
+		t.Helper()
+		clock += 10
+		_, err := repo.RecordDecision(ctx, actorID, recipientID, liked, "")
+		require.NoError(t, err)
+	}
+	decision := func(actorID, recipientID string) *models.Decision {
+		t.Helper()
+		var d models.Decision
+		err := repo.db.Where("actor_user_id = ? AND recipient_user_id = ?", actorID, recipientID).Take(&d).Error
+		if err != nil {
+			return nil
+		}
+		return &d
+	}
+
+	_, err := repo.UndoDecision(ctx, "alice")
+	assert.ErrorIs(t, err, ErrNothingToUndo)
+
+	// undoing a first like removes the row and the match it made
+	decide("bob", "alice", true)
+	decide("alice", "bob", true)
+	undo, err := repo.UndoDecision(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", undo.Undone.RecipientUserID)
+	assert.Nil(t, undo.Restored)
+	assert.Equal(t, Outcome{Mutual: false, Changed: true, Timestamp: clock}, undo.Outcome)
+	assert.Nil(t, decision("alice", "bob"))
+	matches, err := repo.CountMatches(ctx, "bob")
+	require.NoError(t, err)
+	assert.Zero(t, matches)
+
+	// only the last decision can be undone
+	_, err = repo.UndoDecision(ctx, "alice")
+	assert.ErrorIs(t, err, ErrNothingToUndo)
+
+	// undoing an accidental pass brings back the like, with its time, and the match
+	decide("alice", "bob", true)
+	likedAt := clock
+	decide("alice", "bob", false)
+	undo, err = repo.UndoDecision(ctx, "alice")
+	require.NoError(t, err)
+	require.NotNil(t, undo.Restored)
+	assert.Equal(t, models.Decision{ActorUserID: "alice", RecipientUserID: "bob", Liked: true, UnixTimestamp: likedAt}, *undo.Restored)
+	assert.Equal(t, Outcome{Mutual: true, Changed: true, Timestamp: likedAt}, undo.Outcome)
+	assert.Equal(t, undo.Restored, decision("alice", "bob"))
+
+	likers, _, err := repo.GetLikers(ctx, "bob", pagination.Page{})
+	require.NoError(t, err)
+	require.Len(t, likers, 1)
+	assert.Equal(t, uint64(likedAt), likers[0].UnixTimestamp)
+
+	// the last event of the outbox puts alice back into bob's likes
+	var last models.OutboxEvent
+	require.NoError(t, repo.db.Where("op = ?", models.OutboxAddLike).Order("id DESC").Take(&last).Error)
+	assert.Equal(t, "bob", last.RecipientUserID)
+	assert.Equal(t, likedAt, last.UnixTimestamp)
+
+	// an undone like of the history is skipped when replaying it
+	decide("alice", "carol", false)
+	decide("alice", "carol", true)
+	_, err = repo.UndoDecision(ctx, "alice")
+	require.NoError(t, err)
+	decide("alice", "carol", true)
+	undo, err = repo.UndoDecision(ctx, "alice")
+	require.NoError(t, err)
+	require.NotNil(t, undo.Restored)
+	assert.False(t, undo.Restored.Liked)
+
+	// too late
+	decide("alice", "dave", false)
+	clock += 61
+	_, err = repo.UndoDecision(ctx, "alice")
+	assert.ErrorIs(t, err, ErrUndoExpired)
+
+	history, _, err := repo.GetDecisionHistory(ctx, "alice", "bob", nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.DecisionSourceUndo, history[0].Source)
+}
+
+func TestDBRepository_UndoDecision_ExpiredPass(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	repo.config.UndoWindow = time.Minute
+	repo.config.PassExpiry = time.Hour
+
+	var clock int64 = 1000
+	repo.now = func() time.Time { return time.Unix(clock, 0) }
+	decide := func(recipientID string, liked bool) {
+		t.Helper()
+		_, err := repo.RecordDecision(ctx, "alice", recipientID, liked, "")
+		require.NoError(t, err)
+	}
+	decision := func(recipientID string) *models.Decision {
+		t.Helper()
+		var d models.Decision
+		if err := repo.db.Where("actor_user_id = ? AND recipient_user_id = ?", "alice", recipientID).Take(&d).Error; err != nil {
+			return nil
+		}
+		return &d
+	}
+
+	// a pass that expired before the like isn't restored, the pair is undecided again
+	decide("bob", false)
+	clock += 3600
+	decide("bob", true)
+	undo, err := repo.UndoDecision(ctx, "alice")
+	require.NoError(t, err)
+	assert.Nil(t, undo.Restored)
+	assert.Equal(t, Outcome{Changed: true, Timestamp: clock}, undo.Outcome)
+	assert.Nil(t, decision("bob"))
+
+	// a pass still in force is
+	decide("carol", false)
+	clock += 10
+	decide("carol", true)
+	undo, err = repo.UndoDecision(ctx, "alice")
+	require.NoError(t, err)
+	require.NotNil(t, undo.Restored)
+	assert.False(t, undo.Restored.Liked)
+	assert.Equal(t, undo.Restored, decision("carol"))
+}
+
+func TestDBRepository_UndoDecision_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	repo.config.UndoWindow = time.Hour
+
+	for _, liked := range []bool{true, false, true} {
+		_, err := repo.RecordDecision(ctx, "alice", "bob", liked, "")
+		require.NoError(t, err)
+	}
+
+	// a double tap undoes the last like once, the pass before it stays
+	const taps = 5
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, taps)
+	for i := range taps {
+		wg.Go(func() {
+			<-start
+			_, errs[i] = repo.UndoDecision(ctx, "alice")
+		})
+	}
+	close(start)
+	wg.Wait()
+
+	var undone int
+	for _, err := range errs {
+		if err == nil {
+			undone++
+			continue
+		}
+		assert.ErrorIs(t, err, ErrNothingToUndo)
+	}
+	assert.Equal(t, 1, undone)
+
+	var d models.Decision
+	require.NoError(t, repo.db.Where("actor_user_id = ? AND recipient_user_id = ?", "alice", "bob").Take(&d).Error)
+	assert.False(t, d.Liked)
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/endyapina/muzzapp/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DeletionReport counts what DeleteUserData deleted.
+type DeletionReport struct {
+	Decisions       int64 `json:"decisions"`
+	DecisionEvents  int64 `json:"decision_events"`
+	Matches         int64 `json:"matches"`
+	Blocks          int64 `json:"blocks"`
+	ExpiredPasses   int64 `json:"expired_passes"`
+	IdempotencyKeys int64 `json:"idempotency_keys"`
+	OutboxEvents    int64 `json:"outbox_events"`
+	// CachedLikes is how many likes of the user were queued for removal from the sorted
+	// sets of the users they liked
+	CachedLikes int64 `json:"cached_likes"`
+	// Remaining counts the rows still holding the user id once the deletion finished. it
+	// is zero unless the user was written to while they were being deleted
+	Remaining int64 `json:"remaining"`
+}
+
+// userTable is a table holding user ids: its model, the columns of its primary key and the
+// condition selecting the rows of a user, named @user.
+type userTable struct {
+	model any
+	keys  []string
+	where string
+}
+
+// userTables are the tables DeleteUserData erases besides decisions. the outbox only
+// loses the events already relayed: pending ones must still reach redis, the removals
+// queued by the deletion come after them, and all of them are purged after
+// OutboxRetention.
+var userTables = []userTable{
+	{&models.DecisionEvent{}, []string{"id"}, "actor_user_id = @user OR recipient_user_id = @user"},
+	{&models.Match{}, []string{"user_id", "matched_user_id"}, "user_id = @user OR matched_user_id = @user"},
+	{&models.Block{}, []string{"blocker_user_id", "blocked_user_id"}, "blocker_user_id = @user OR blocked_user_id = @user"},
+	{&models.ExpiredPass{}, []string{"id"}, "actor_user_id = @user OR recipient_user_id = @user"},
+	{&models.IdempotencyKey{}, []string{"actor_user_id", "idempotency_key"}, "actor_user_id = @user OR recipient_user_id = @user"},
+	{&models.OutboxEvent{}, []string{"id"}, "processed_at IS NOT NULL AND (actor_user_id = @user OR recipient_user_id = @user)"},
+}
+
+// DeleteUserData erases every row holding the user id, in both directions, and queues the
+// outbox events removing the user from the cache: their likes leave the sorted sets of
+// the users they liked, and their own sets are dropped.
+//
+// rows are deleted DeletionBatchSize at a time, each batch in its own transaction, so a
+// deletion that fails halfway can simply be run again. the report ends with a recount of
+// the rows left, which the caller can check to prove the user is gone.
+func (r *DBRepository) DeleteUserData(ctx context.Context, userID string) (DeletionReport, error) {
+	var report DeletionReport
+	var err error
+
+	report.Decisions, report.CachedLikes, err = r.deleteDecisions(ctx, userID)
+	if err != nil {
+		return report, err
+	}
+
+	counts := []*int64{&report.DecisionEvents, &report.Matches, &report.Blocks, &report.ExpiredPasses, &report.IdempotencyKeys, &report.OutboxEvents}
+	for i, table := range userTables {
+		if *counts[i], err = r.deleteBatched(ctx, table, userID); err != nil {
+			return report, err
+		}
+	}
+
+	// queued after the decisions are gone, so no like of the user can be relayed after it
+	if err := r.db.WithContext(ctx).Create(&models.OutboxEvent{
+		Op:              models.OutboxClearLikes,
+		RecipientUserID: userID,
+		UnixTimestamp:   r.now().Unix(),
+	}).Error; err != nil {
+		return report, err
+	}
+
+	report.Remaining, err = r.countUserRows(ctx, userID)
+	return report, err
+}
+
+// ScanUserDecisions returns up to limit decisions the user made, or received when received
+// is set, ordered by the other user of the pair and starting strictly after afterUserID.
+// passes are included. it is used to export the data of a user in batches.
+func (r *DBRepository) ScanUserDecisions(ctx context.Context, userID string, received bool, afterUserID string, limit int) ([]models.Decision, error) {
+	userColumn, otherColumn := "actor_user_id", "recipient_user_id"
+	if received {
+		userColumn, otherColumn = otherColumn, userColumn
+	}
+
+	var decisions []models.Decision
+	err := r.db.WithContext(ctx).
+		Where(userColumn+" = ? AND "+otherColumn+" > ?", userID, afterUserID).
+		Order(otherColumn + " ASC").
+		Limit(limit).
+		Find(&decisions).Error
+	return decisions, err
+}
+
+// deleteDecisions deletes the decisions the user made or received, together with the
+// outbox events removing the user's likes from the cache, and returns how many decisions
+// and likes were deleted.
+func (r *DBRepository) deleteDecisions(ctx context.Context, userID string) (int64, int64, error) {
+	limit := r.config.DeletionBatchSize
+	var deleted, likes int64
+
+	for {
+		var batch []models.Decision
+		err := r.withTx(ctx, func(tx *gorm.DB) error {
+			batch = nil
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("actor_user_id = ? OR recipient_user_id = ?", userID, userID).
+				Limit(limit).
+				Find(&batch).Error; err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				return nil
+			}
+
+			var events []models.OutboxEvent
+			keys := make([][]any, len(batch))
+			for i, d := range batch {
+				keys[i] = []any{d.ActorUserID, d.RecipientUserID}
+				if d.ActorUserID == userID && d.Liked {
+					events = append(events,
+						models.OutboxEvent{Op: models.OutboxRemoveLike, RecipientUserID: d.RecipientUserID, ActorUserID: userID, UnixTimestamp: d.UnixTimestamp},
+						models.OutboxEvent{Op: models.OutboxRemoveNewLike, RecipientUserID: d.RecipientUserID, ActorUserID: userID, UnixTimestamp: d.UnixTimestamp},
+					)
+				}
+			}
+			if len(events) > 0 {
+				if err := tx.Create(events).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Where("(actor_user_id, recipient_user_id) IN ?", keys).Delete(&models.Decision{}).Error
+		})
+		if err != nil {
+			return deleted, likes, err
+		}
+
+		deleted += int64(len(batch))
+		for _, d := range batch {
+			if d.ActorUserID == userID && d.Liked {
+				likes++
+			}
+		}
+		if len(batch) == 0 || len(batch) < limit {
+			return deleted, likes, nil
+		}
+	}
+}
+
+// deleteBatched deletes the rows of a table holding the user id, a batch at a time, and
+// returns how many were deleted. mysql can't limit a delete by a subquery, so the primary
+// keys of each batch are read first.
+func (r *DBRepository) deleteBatched(ctx context.Context, table userTable, userID string) (int64, error) {
+	limit := r.config.DeletionBatchSize
+	user := map[string]any{"user": userID}
+	var deleted int64
+
+	for {
+		var rows []map[string]any
+		var n int64
+		err := r.withTx(ctx, func(tx *gorm.DB) error {
+			rows = nil
+			if err := tx.Model(table.model).Select(table.keys).Where(table.where, user).Limit(limit).Find(&rows).Error; err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return nil
+			}
+
+			keys := make([][]any, len(rows))
+			for i, row := range rows {
+				for _, column := range table.keys {
+					keys[i] = append(keys[i], row[column])
+				}
+			}
+			res := tx.Where(fmt.Sprintf("(%s) IN ?", strings.Join(table.keys, ", ")), keys).Delete(table.model)
+			n = res.RowsAffected
+			return res.Error
+		})
+		deleted += n
+		if err != nil || len(rows) == 0 || len(rows) < limit {
+			return deleted, err
+		}
+	}
+}
+
+// countUserRows counts the rows still holding the user id, outside of the outbox.
+func (r *DBRepository) countUserRows(ctx context.Context, userID string) (int64, error) {
+	db := r.db.WithContext(ctx)
+	user := map[string]any{"user": userID}
+
+	var total int64
+	if err := db.Model(&models.Decision{}).Where("actor_user_id = @user OR recipient_user_id = @user", user).Count(&total).Error; err != nil {
+		return 0, err
+	}
+	for _, table := range userTables {
+		// the relay may already have processed the removals the deletion queued
+		if _, ok := table.model.(*models.OutboxEvent); ok {
+			continue
+		}
+		var count int64
+		if err := db.Model(table.model).Where(table.where, user).Count(&count).Error; err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
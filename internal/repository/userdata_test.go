@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/pagination"
+)
+
+func TestDBRepository_DeleteUserData(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	// small batches so every table takes several of them
+	repo.config.DeletionBatchSize = 2
+
+	decisions := []struct {
+		actorID, recipientID string
+		liked                bool
+		idempotencyKey       string
+	}{
+		{"alice", "bob", true, ""},
+		{"bob", "alice", true, ""},
+		{"alice", "carol", true, ""},
+		{"dave", "alice", true, ""},
+		{"alice", "eve", false, ""},
+		{"alice", "frank", true, "retry-1"},
+		{"bob", "carol", true, ""},
+	}
+	for _, d := range decisions {
+		_, err := repo.RecordDecision(ctx, d.actorID, d.recipientID, d.liked, d.idempotencyKey)
+		require.NoError(t, err)
+	}
+	_, err := repo.Block(ctx, "eve", "alice", "")
+	require.NoError(t, err)
+
+	// relayed events holding alice's id are erased, pending ones are left for the relay
+	_, err = repo.ProcessOutbox(ctx, 1000, func([]models.OutboxEvent) error { return nil })
+	require.NoError(t, err)
+	var relayed int64
+	require.NoError(t, repo.db.Model(&models.OutboxEvent{}).
+		Where("actor_user_id = ? OR recipient_user_id = ?", "alice", "alice").
+		Count(&relayed).Error)
+
+	report, err := repo.DeleteUserData(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, DeletionReport{
+		Decisions:       6,
+		DecisionEvents:  6,
+		Matches:         2,
+		Blocks:          1,
+		IdempotencyKeys: 1,
+		OutboxEvents:    relayed,
+		CachedLikes:     3,
+	}, report)
+
+	// the likes of other users are untouched
+	likers, _, err := repo.GetLikers(ctx, "carol", pagination.Page{})
+	require.NoError(t, err)
+	require.Len(t, likers, 1)
+	assert.Equal(t, "bob", likers[0].ActorId)
+
+	// the queued events drop alice from the sets of bob, carol and frank, then her own sets
+	var pending []models.OutboxEvent
+	require.NoError(t, repo.db.Where("processed_at IS NULL").Order("id ASC").Find(&pending).Error)
+	last := pending[len(pending)-1]
+	removed := map[string]bool{}
+	for _, e := range pending[:len(pending)-1] {
+		assert.Equal(t, "alice", e.ActorUserID)
+		assert.Contains(t, []string{models.OutboxRemoveLike, models.OutboxRemoveNewLike}, e.Op)
+		removed[e.RecipientUserID] = true
+	}
+	assert.Equal(t, map[string]bool{"bob": true, "carol": true, "frank": true}, removed)
+	assert.Equal(t, models.OutboxClearLikes, last.Op)
+	assert.Equal(t, "alice", last.RecipientUserID)
+
+	// deleting again finds nothing left
+	report, err = repo.DeleteUserData(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, DeletionReport{}, report)
+}
+
+func TestDBRepository_ScanUserDecisions(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	for _, d := range []struct {
+		actorID, recipientID string
+		liked                bool
+	}{
+		{"alice", "dave", true},
+		{"alice", "bob", false},
+		{"alice", "carol", true},
+		{"carol", "alice", false},
+		{"bob", "carol", true},
+	} {
+		_, err := repo.RecordDecision(ctx, d.actorID, d.recipientID, d.liked, "")
+		require.NoError(t, err)
+	}
+
+	scan := func(received bool) []string {
+		var got []string
+		var after string
+		for {
+			batch, err := repo.ScanUserDecisions(ctx, "alice", received, after, 2)
+			require.NoError(t, err)
+			for _, d := range batch {
+				got = append(got, d.ActorUserID+">"+d.RecipientUserID)
+			}
+			if len(batch) < 2 {
+				return got
+			}
+			after = batch[len(batch)-1].RecipientUserID
+			if received {
+				after = batch[len(batch)-1].ActorUserID
+			}
+		}
+	}
+
+	assert.Equal(t, []string{"alice>bob", "alice>carol", "alice>dave"}, scan(false))
+	assert.Equal(t, []string{"carol>alice"}, scan(true))
+}
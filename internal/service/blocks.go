@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/endyapina/muzzapp/internal/pagination"
+	redis_cache "github.com/endyapina/muzzapp/internal/redis"
+	"github.com/endyapina/muzzapp/internal/repository"
+	pb "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErrSelfBlock is returned when a user blocks or unblocks themselves.
+var ErrSelfBlock = errors.New("user and blocked user must be different users")
+
+// BlockUser blocks a user on behalf of another one, with the reason they reported. from
+// then on neither of them shows up in the other's likes, new likes or matches. like
+// decisions, the cache is updated by the outbox relay, and the watchers of both users see
+// the likes the block hid withdrawn, the same way an unlike withdraws them.
+func (s *ExploreService) BlockUser(ctx context.Context, userID, blockedUserID, reason string) error {
+	ctx, span := startSpan(ctx, "BlockUser", attribute.String("user.id", userID), attribute.String("blocked_user.id", blockedUserID))
+	defer span.End()
+
+	if userID == blockedUserID {
+		return ErrSelfBlock
+	}
+
+	outcome, err := s.repo.Block(ctx, userID, blockedUserID, reason)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to block user", "user_id", userID, "blocked_user_id", blockedUserID, "error", err)
+		return err
+	}
+	s.logger.InfoContext(ctx, "user blocked", "user_id", userID, "blocked_user_id", blockedUserID, "reported", reason != "")
+	s.wakeRelay()
+
+	var events []redis_cache.LikeEvent
+	for _, d := range outcome.Hidden {
+		unliked := repository.Outcome{Changed: true, Timestamp: outcome.Timestamp}
+		events = append(events, likeEvents(d.ActorUserID, d.RecipientUserID, false, unliked)...)
+	}
+	s.publish(ctx, events, "user_id", userID, "blocked_user_id", blockedUserID)
+	return nil
+}
+
+// UnblockUser lifts a block. the likes and match it hid come back, unless the blocked user
+// blocked the user too.
+func (s *ExploreService) UnblockUser(ctx context.Context, userID, blockedUserID string) error {
+	ctx, span := startSpan(ctx, "UnblockUser", attribute.String("user.id", userID), attribute.String("blocked_user.id", blockedUserID))
+	defer span.End()
+
+	if userID == blockedUserID {
+		return ErrSelfBlock
+	}
+
+	if err := s.repo.Unblock(ctx, userID, blockedUserID); err != nil {
+		s.logger.ErrorContext(ctx, "failed to unblock user", "user_id", userID, "blocked_user_id", blockedUserID, "error", err)
+		return err
+	}
+	s.logger.InfoContext(ctx, "user unblocked", "user_id", userID, "blocked_user_id", blockedUserID)
+	s.wakeRelay()
+	return nil
+}
+
+// ListBlocked lists the users a user blocked, oldest block first.
+func (s *ExploreService) ListBlocked(ctx context.Context, userID string, paginationToken string) ([]*pb.ListBlockedResponse_Blocked, string, error) {
+	ctx, span := startSpan(ctx, "ListBlocked", attribute.String("user.id", userID))
+	defer span.End()
+
+	owner := blocksOwner(userID)
+	after, err := s.cursors.Decode(paginationToken, owner)
+	if err != nil {
+		return nil, "", err
+	}
+
+	results, more, err := s.repo.ListBlocked(ctx, userID, after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var blocked []*pb.ListBlockedResponse_Blocked
+	for i := range results {
+		blocked = append(blocked, &results[i])
+	}
+
+	var nextToken string
+	if more && len(blocked) > 0 {
+		last := blocked[len(blocked)-1]
+		nextToken = s.cursors.Encode(pagination.Cursor{
+			Timestamp: int64(last.UnixTimestamp),
+			ID:        last.UserId,
+			Direction: pagination.Ascending,
+			Owner:     owner,
+		})
+	}
+	return blocked, nextToken, nil
+}
+
+// blocksOwner is the cursor owner of a blocked users listing, so a matches token of the
+// same user can't be replayed against it.
+func blocksOwner(userID string) string {
+	return "blocks:" + userID
+}
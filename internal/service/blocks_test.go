@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/pagination"
+	"github.com/endyapina/muzzapp/internal/redis"
+	redis_mocks "github.com/endyapina/muzzapp/internal/redis/mocks"
+	"github.com/endyapina/muzzapp/internal/repository"
+	db_mocks "github.com/endyapina/muzzapp/internal/repository/mocks"
+)
+
+func TestExploreService_BlockUser(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		blockedUserID string
+		mockHidden    []models.Decision
+		mockErr       error
+		wantEvents    []redis.LikeEvent
+		wantErr       error
+	}{
+		{name: "success - nothing to withdraw", blockedUserID: "user2"},
+		{
+			name:          "success - hidden likes are withdrawn",
+			blockedUserID: "user2",
+			mockHidden: []models.Decision{
+				{ActorUserID: "user1", RecipientUserID: "user2", Liked: true, UnixTimestamp: 100},
+				{ActorUserID: "user2", RecipientUserID: "user1", Liked: true, UnixTimestamp: 200},
+			},
+			wantEvents: []redis.LikeEvent{
+				{Type: redis.LikeEventUnliked, RecipientID: "user2", ActorID: "user1", Timestamp: 1000},
+				{Type: redis.LikeEventUnliked, RecipientID: "user1", ActorID: "user2", Timestamp: 1000},
+			},
+		},
+		{name: "self block", blockedUserID: "user1", wantErr: ErrSelfBlock},
+		{name: "db error", blockedUserID: "user2", mockErr: errors.New("db error"), wantErr: errors.New("db error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			if tt.blockedUserID != "user1" {
+				mockRepo.EXPECT().
+					Block(mock.Anything, "user1", tt.blockedUserID, "spam").
+					Return(repository.BlockOutcome{Hidden: tt.mockHidden, Timestamp: 1000}, tt.mockErr).
+					Once()
+				mockRepo.EXPECT().Unblock(mock.Anything, "user1", tt.blockedUserID).Return(tt.mockErr).Once()
+			}
+			if len(tt.wantEvents) > 0 {
+				mockCache.EXPECT().PublishLikeEvents(mock.Anything, tt.wantEvents).Return(nil).Once()
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{PaginationSecret: testSecret})
+			assert.Equal(t, tt.wantErr, svc.BlockUser(ctx, "user1", tt.blockedUserID, "spam"))
+			assert.Equal(t, tt.wantErr, svc.UnblockUser(ctx, "user1", tt.blockedUserID))
+		})
+	}
+}
+
+func TestExploreService_ListBlocked(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		paginationToken string
+		wantAfter       string
+		mockBlocked     []repository.Blocked
+		mockMore        bool
+		wantNextAfter   string
+		wantErr         error
+	}{
+		{
+			name: "first page",
+			mockBlocked: []repository.Blocked{
+				{UserId: "user2", UnixTimestamp: 1000},
+				{UserId: "user3", UnixTimestamp: 1100},
+			},
+			mockMore:      true,
+			wantNextAfter: "user3",
+		},
+		{
+			name:            "last page",
+			paginationToken: testToken(blocksOwner("user1"), 1100, "user3"),
+			wantAfter:       "user3",
+			mockBlocked:     []repository.Blocked{{UserId: "user4", UnixTimestamp: 1200}},
+		},
+		{
+			name:            "matches token",
+			paginationToken: testToken(matchesOwner("user1"), 1100, "user3"),
+			wantErr:         pagination.ErrForeignCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().
+					ListBlocked(mock.Anything, "user1", mock.MatchedBy(func(after *pagination.Cursor) bool {
+						return (after == nil && tt.wantAfter == "") || (after != nil && after.ID == tt.wantAfter)
+					})).
+					Return(tt.mockBlocked, tt.mockMore, nil)
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{PaginationSecret: testSecret})
+			got, nextToken, err := svc.ListBlocked(ctx, "user1", tt.paginationToken)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, got, len(tt.mockBlocked))
+			if tt.wantNextAfter == "" {
+				assert.Empty(t, nextToken)
+				return
+			}
+			assert.Equal(t, tt.wantNextAfter, decodeToken(t, nextToken, blocksOwner("user1")).ID)
+		})
+	}
+}
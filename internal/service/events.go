@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	redis_cache "github.com/endyapina/muzzapp/internal/redis"
+	"github.com/endyapina/muzzapp/internal/repository"
+	pb "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ErrShuttingDown ends the WatchLikedYou streams of a replica that is shutting down, the
+// clients reconnect to another one.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// likeEvents returns the events a decision publishes. only decisions that add or withdraw
+// a like publish anything: a pass of someone who was never liked stays invisible to them,
+// and a repeated or replayed like doesn't notify anyone twice. the events carry the time
+// the decision is stored with, so a like event matches the liker's place in ListLikedYou.
+func likeEvents(actorID, recipientID string, liked bool, outcome repository.Outcome) []redis_cache.LikeEvent {
+	if !outcome.Changed {
+		return nil
+	}
+	ts := outcome.Timestamp
+	if !liked {
+		return []redis_cache.LikeEvent{
+			{Type: redis_cache.LikeEventUnliked, RecipientID: recipientID, ActorID: actorID, Timestamp: ts},
+		}
+	}
+
+	events := []redis_cache.LikeEvent{
+		{Type: redis_cache.LikeEventLiked, RecipientID: recipientID, ActorID: actorID, Timestamp: ts},
+	}
+	if outcome.Mutual {
+		events = append(events,
+			redis_cache.LikeEvent{Type: redis_cache.LikeEventMatched, RecipientID: recipientID, ActorID: actorID, Timestamp: ts},
+			redis_cache.LikeEvent{Type: redis_cache.LikeEventMatched, RecipientID: actorID, ActorID: recipientID, Timestamp: ts},
+		)
+	}
+	return events
+}
+
+// publish sends like events to their watchers. the decision is already committed, so a
+// failure is only logged, with args naming the decision: watchers miss the event, and see
+// the change the next time they list their likes.
+func (s *ExploreService) publish(ctx context.Context, events []redis_cache.LikeEvent, args ...any) {
+	if len(events) == 0 {
+		return
+	}
+	// the client hanging up after the commit shouldn't keep the events from going out
+	if err := s.cache.PublishLikeEvents(context.WithoutCancel(ctx), events); err != nil {
+		args = append(args, "events", len(events), "error", err)
+		s.logger.WarnContext(ctx, "failed to publish like events", args...)
+	}
+}
+
+// WatchLikedYou calls send with every like, unlike and match of the recipient as they
+// happen, until the client goes away, send fails or the server shuts down.
+func (s *ExploreService) WatchLikedYou(ctx context.Context, recipientID string, send func(*pb.WatchLikedYouResponse) error) error {
+	ctx, span := startSpan(ctx, "WatchLikedYou", attribute.String("recipient.id", recipientID))
+	defer span.End()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := context.AfterFunc(s.watches, func() { cancel(ErrShuttingDown) })
+	defer stop()
+
+	err := s.cache.WatchLikeEvents(ctx, recipientID, func(event redis_cache.LikeEvent) error {
+		return send(&pb.WatchLikedYouResponse{
+			Type:          watchEventTypes[event.Type],
+			ActorId:       event.ActorID,
+			UnixTimestamp: uint64(event.Timestamp),
+		})
+	})
+	if cause := context.Cause(ctx); errors.Is(cause, ErrShuttingDown) {
+		return cause
+	}
+	return err
+}
+
+// CloseWatches ends every WatchLikedYou stream with ErrShuttingDown. streams never end on
+// their own, so they are closed before the server drains its requests.
+func (s *ExploreService) CloseWatches() {
+	s.stopWatches()
+}
+
+var watchEventTypes = map[redis_cache.LikeEventType]pb.WatchLikedYouResponse_Type{
+	redis_cache.LikeEventLiked:   pb.WatchLikedYouResponse_TYPE_LIKED,
+	redis_cache.LikeEventUnliked: pb.WatchLikedYouResponse_TYPE_UNLIKED,
+	redis_cache.LikeEventMatched: pb.WatchLikedYouResponse_TYPE_MATCHED,
+}
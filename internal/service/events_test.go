@@ -0,0 +1,123 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/redis"
+	redis_mocks "github.com/endyapina/muzzapp/internal/redis/mocks"
+	"github.com/endyapina/muzzapp/internal/repository"
+	db_mocks "github.com/endyapina/muzzapp/internal/repository/mocks"
+	pb "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
+)
+
+func TestLikeEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		liked   bool
+		outcome repository.Outcome
+		want    []redis.LikeEvent
+	}{
+		{
+			name:    "new like",
+			liked:   true,
+			outcome: repository.Outcome{Changed: true, Timestamp: 100},
+			want:    []redis.LikeEvent{{Type: redis.LikeEventLiked, RecipientID: "bob", ActorID: "alice", Timestamp: 100}},
+		},
+		{
+			name:    "new like making a match notifies both users",
+			liked:   true,
+			outcome: repository.Outcome{Mutual: true, Changed: true, Timestamp: 100},
+			want: []redis.LikeEvent{
+				{Type: redis.LikeEventLiked, RecipientID: "bob", ActorID: "alice", Timestamp: 100},
+				{Type: redis.LikeEventMatched, RecipientID: "bob", ActorID: "alice", Timestamp: 100},
+				{Type: redis.LikeEventMatched, RecipientID: "alice", ActorID: "bob", Timestamp: 100},
+			},
+		},
+		{
+			name:    "withdrawn like",
+			outcome: repository.Outcome{Changed: true, Timestamp: 100},
+			want:    []redis.LikeEvent{{Type: redis.LikeEventUnliked, RecipientID: "bob", ActorID: "alice", Timestamp: 100}},
+		},
+		{
+			// an undo bringing back a like publishes it with its original time
+			name:    "restored like keeps its time",
+			liked:   true,
+			outcome: repository.Outcome{Changed: true, Timestamp: 50},
+			want:    []redis.LikeEvent{{Type: redis.LikeEventLiked, RecipientID: "bob", ActorID: "alice", Timestamp: 50}},
+		},
+		{name: "repeated like", liked: true, outcome: repository.Outcome{Mutual: true, Timestamp: 100}},
+		{name: "pass of someone never liked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, likeEvents("alice", "bob", tt.liked, tt.outcome))
+		})
+	}
+}
+
+func TestExploreService_PutDecision_PublishFailure(t *testing.T) {
+	mockRepo := db_mocks.NewRepository(t)
+	mockCache := redis_mocks.NewRepository(t)
+
+	mockRepo.EXPECT().
+		RecordDecision(mock.Anything, "alice", "bob", true, "").
+		Return(repository.Outcome{Changed: true}, nil).
+		Once()
+	mockCache.EXPECT().PublishLikeEvents(mock.Anything, mock.Anything).Return(errors.New("redis error")).Once()
+
+	var logs bytes.Buffer
+	svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{})
+	svc.logger = slog.New(slog.NewJSONHandler(&logs, nil))
+
+	// the decision is committed, so the failure is logged rather than returned
+	_, err := svc.PutDecision(context.Background(), "alice", "bob", true, "")
+	require.NoError(t, err)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "failed to publish like events", entry["msg"])
+	assert.Equal(t, "alice", entry["actor_id"])
+	assert.Equal(t, "bob", entry["recipient_id"])
+	assert.Equal(t, "redis error", entry["error"])
+}
+
+func TestExploreService_WatchLikedYou(t *testing.T) {
+	mockCache := redis_mocks.NewRepository(t)
+	svc := newTestService(t, db_mocks.NewRepository(t), mockCache, &config.AppConfig{})
+
+	mockCache.EXPECT().
+		WatchLikeEvents(mock.Anything, "alice", mock.Anything).
+		RunAndReturn(func(ctx context.Context, recipientID string, fn func(redis.LikeEvent) error) error {
+			if err := fn(redis.LikeEvent{Type: redis.LikeEventMatched, RecipientID: "alice", ActorID: "bob", Timestamp: 100}); err != nil {
+				return err
+			}
+			// the stream stays open until the server shuts down
+			svc.CloseWatches()
+			<-ctx.Done()
+			return ctx.Err()
+		}).
+		Once()
+
+	var sent []*pb.WatchLikedYouResponse
+	err := svc.WatchLikedYou(context.Background(), "alice", func(resp *pb.WatchLikedYouResponse) error {
+		sent = append(sent, resp)
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrShuttingDown)
+
+	require.Len(t, sent, 1)
+	assert.Equal(t, pb.WatchLikedYouResponse_TYPE_MATCHED, sent[0].Type)
+	assert.Equal(t, "bob", sent[0].ActorId)
+	assert.Equal(t, uint64(100), sent[0].UnixTimestamp)
+}
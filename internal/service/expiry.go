@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/endyapina/muzzapp/internal/models"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// GetDecision returns the decision in force of the actor about the recipient, or nil when
+// they haven't decided yet or their pass is older than PassExpiry, so the recipient can be
+// shown to them again.
+func (s *ExploreService) GetDecision(ctx context.Context, actorID, recipientID string) (*models.Decision, error) {
+	ctx, span := startSpan(ctx, "GetDecision", attribute.String("actor.id", actorID), attribute.String("recipient.id", recipientID))
+	defer span.End()
+
+	d, err := s.repo.GetDecision(ctx, actorID, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil || s.passExpired(*d, time.Now()) {
+		return nil, nil
+	}
+	return d, nil
+}
+
+// HasDecided reports whether the actor decided about the recipient, see GetDecision.
+func (s *ExploreService) HasDecided(ctx context.Context, actorID, recipientID string) (bool, error) {
+	d, err := s.GetDecision(ctx, actorID, recipientID)
+	return d != nil, err
+}
+
+// PassExpiresAt returns when a pass stops counting, and false for likes or when passes
+// never expire.
+func (s *ExploreService) PassExpiresAt(d models.Decision) (time.Time, bool) {
+	if d.Liked || s.config.PassExpiry <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(d.UnixTimestamp, 0).Add(s.config.PassExpiry), true
+}
+
+// passExpired reports whether d is a pass that expired by now. expired passes still in the
+// decisions table are waiting for the sweeper.
+func (s *ExploreService) passExpired(d models.Decision, now time.Time) bool {
+	expiresAt, ok := s.PassExpiresAt(d)
+	return ok && !now.Before(expiresAt)
+}
+
+// RunPassSweeper archives expired passes every PassSweepInterval until ctx is cancelled.
+// it does nothing when passes never expire.
+func (s *ExploreService) RunPassSweeper(ctx context.Context) {
+	if s.config.PassExpiry <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.PassSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		archived, err := s.SweepExpiredPasses(ctx)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "failed to sweep expired passes", "error", err)
+		} else if archived > 0 {
+			s.logger.InfoContext(ctx, "expired passes archived", "passes", archived)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SweepExpiredPasses moves every pass older than PassExpiry to expired_passes,
+// PassSweepBatchSize at a time, and returns how many were moved. a sweep that fails
+// halfway keeps the batches it already moved.
+func (s *ExploreService) SweepExpiredPasses(ctx context.Context) (int, error) {
+	before := time.Now().Add(-s.config.PassExpiry).Unix()
+	var total int
+	for {
+		archived, err := s.repo.ArchiveExpiredPasses(ctx, before, s.config.PassSweepBatchSize)
+		total += archived
+		if err != nil || archived == 0 || archived < s.config.PassSweepBatchSize {
+			return total, err
+		}
+		if ctx.Err() != nil {
+			return total, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/models"
+	redis_mocks "github.com/endyapina/muzzapp/internal/redis/mocks"
+	db_mocks "github.com/endyapina/muzzapp/internal/repository/mocks"
+)
+
+func TestExploreService_GetDecision(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	day := int64(24 * 60 * 60)
+
+	tests := []struct {
+		name        string
+		passExpiry  time.Duration
+		mockResult  *models.Decision
+		mockErr     error
+		wantDecided bool
+		wantExpiry  bool
+		wantErr     bool
+	}{
+		{name: "undecided", passExpiry: 90 * 24 * time.Hour},
+		{name: "old like", passExpiry: 90 * 24 * time.Hour, mockResult: &models.Decision{Liked: true, UnixTimestamp: now - 365*day}, wantDecided: true},
+		{name: "recent pass", passExpiry: 90 * 24 * time.Hour, mockResult: &models.Decision{UnixTimestamp: now - 89*day}, wantDecided: true, wantExpiry: true},
+		{name: "expired pass", passExpiry: 90 * 24 * time.Hour, mockResult: &models.Decision{UnixTimestamp: now - 91*day}},
+		{name: "passes never expire", mockResult: &models.Decision{UnixTimestamp: now - 365*day}, wantDecided: true},
+		{name: "db error", passExpiry: 90 * 24 * time.Hour, mockErr: errors.New("db error"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			mockRepo.EXPECT().GetDecision(mock.Anything, "user1", "user2").Return(tt.mockResult, tt.mockErr).Twice()
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{PassExpiry: tt.passExpiry})
+			d, err := svc.GetDecision(ctx, "user1", "user2")
+			decided, hasErr := svc.HasDecided(ctx, "user1", "user2")
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Error(t, hasErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, hasErr)
+			assert.Equal(t, tt.wantDecided, decided)
+			if !tt.wantDecided {
+				assert.Nil(t, d)
+				return
+			}
+			assert.Equal(t, tt.mockResult, d)
+			_, expires := svc.PassExpiresAt(*d)
+			assert.Equal(t, tt.wantExpiry, expires)
+		})
+	}
+}
+
+func TestExploreService_SweepExpiredPasses(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		batchSize int
+		batches   []int
+		mockErr   error
+		wantCount int
+		wantErr   bool
+	}{
+		{name: "nothing expired", batchSize: 2, batches: []int{0}},
+		{name: "drains every batch", batchSize: 2, batches: []int{2, 2, 1}, wantCount: 5},
+		{name: "full last batch", batchSize: 2, batches: []int{2, 0}, wantCount: 2},
+		{name: "zero batch size", batchSize: 0, batches: []int{0}},
+		{name: "db error keeps archived batchSize: 2, batches", batchSize: 2, batches: []int{2, 0}, mockErr: errors.New("db error"), wantCount: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			before := time.Now().Add(-time.Hour).Unix()
+			for i, n := range tt.batches {
+				var err error
+				if i == len(tt.batches)-1 {
+					err = tt.mockErr
+				}
+				mockRepo.EXPECT().ArchiveExpiredPasses(mock.Anything, mock.MatchedBy(func(cutoff int64) bool {
+					return cutoff >= before && cutoff <= before+5
+				}), tt.batchSize).Return(n, err).Once()
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{PassExpiry: time.Hour, PassSweepBatchSize: tt.batchSize})
+			count, err := svc.SweepExpiredPasses(ctx)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
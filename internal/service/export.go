@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/endyapina/muzzapp/internal/models"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ExportFormat is the file format of a data export.
+type ExportFormat string
+
+const (
+	ExportJSONLines ExportFormat = "jsonl"
+	ExportCSV       ExportFormat = "csv"
+)
+
+// ExportRecord is a decision in a data export, made or received by the exported user.
+type ExportRecord struct {
+	Direction       string `json:"direction"`
+	ActorUserID     string `json:"actor_user_id"`
+	RecipientUserID string `json:"recipient_user_id"`
+	Decision        string `json:"decision"`
+	UnixTimestamp   int64  `json:"unix_timestamp"`
+	Time            string `json:"time"`
+	// Expired is set on passes the sweeper archived after PassExpiry
+	Expired bool `json:"expired"`
+}
+
+// exportColumns is the header of csv exports, in the order of ExportRecord's fields.
+var exportColumns = []string{"direction", "actor_user_id", "recipient_user_id", "decision", "unix_timestamp", "time", "expired"}
+
+// ExportUserData writes every decision the user made and then every decision they
+// received, passes included, to w in the given format and returns how many were written.
+// expired passes, which the sweeper moved out of the decisions table, follow in the same
+// order. the decisions are read in batches of ExportBatchSize, so an export of a busy user
+// isn't held in memory; it isn't a snapshot, a decision recorded meanwhile may or may not
+// be in it.
+func (s *ExploreService) ExportUserData(ctx context.Context, userID string, format ExportFormat, w io.Writer) (int, error) {
+	ctx, span := startSpan(ctx, "ExportUserData", attribute.String("user.id", userID), attribute.String("export.format", string(format)))
+	defer span.End()
+
+	encoder, err := newExportEncoder(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, export := range []func(context.Context, string, bool, *exportEncoder) (int, error){s.exportDecisions, s.exportExpiredPasses} {
+		for _, received := range []bool{false, true} {
+			n, err := export(ctx, userID, received, encoder)
+			count += n
+			if err != nil {
+				return count, err
+			}
+		}
+	}
+
+	span.SetAttributes(attribute.Int("export.decisions", count))
+	return count, encoder.flush()
+}
+
+// exportDecisions writes the decisions the user made, or received, and returns how many
+// were written.
+func (s *ExploreService) exportDecisions(ctx context.Context, userID string, received bool, encoder *exportEncoder) (int, error) {
+	var count int
+	var after string
+	for {
+		batch, err := s.repo.ScanUserDecisions(ctx, userID, received, after, s.config.ExportBatchSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, d := range batch {
+			if err := encoder.encode(exportRecord(d, received)); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(batch) == 0 || len(batch) < s.config.ExportBatchSize {
+			return count, nil
+		}
+		after = batch[len(batch)-1].RecipientUserID
+		if received {
+			after = batch[len(batch)-1].ActorUserID
+		}
+	}
+}
+
+// exportExpiredPasses writes the archived passes the user made, or received, and returns
+// how many were written.
+func (s *ExploreService) exportExpiredPasses(ctx context.Context, userID string, received bool, encoder *exportEncoder) (int, error) {
+	var count int
+	var after uint64
+	for {
+		batch, err := s.repo.ScanUserExpiredPasses(ctx, userID, received, after, s.config.ExportBatchSize)
+		if err != nil {
+			return count, err
+		}
+
+		for _, p := range batch {
+			record := exportRecord(models.Decision{
+				ActorUserID:     p.ActorUserID,
+				RecipientUserID: p.RecipientUserID,
+				UnixTimestamp:   p.UnixTimestamp,
+			}, received)
+			record.Expired = true
+			if err := encoder.encode(record); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(batch) == 0 || len(batch) < s.config.ExportBatchSize {
+			return count, nil
+		}
+		after = batch[len(batch)-1].ID
+	}
+}
+
+// exportRecord describes a decision of an export.
+func exportRecord(d models.Decision, received bool) ExportRecord {
+	record := ExportRecord{
+		Direction:       "made",
+		ActorUserID:     d.ActorUserID,
+		RecipientUserID: d.RecipientUserID,
+		Decision:        "pass",
+		UnixTimestamp:   d.UnixTimestamp,
+		Time:            time.Unix(d.UnixTimestamp, 0).UTC().Format(time.RFC3339),
+	}
+	if received {
+		record.Direction = "received"
+	}
+	if d.Liked {
+		record.Decision = "like"
+	}
+	return record
+}
+
+// exportEncoder writes the records of an export in its format.
+type exportEncoder struct {
+	encode func(ExportRecord) error
+	flush  func() error
+}
+
+func newExportEncoder(format ExportFormat, w io.Writer) (*exportEncoder, error) {
+	switch format {
+	case ExportJSONLines:
+		encoder := json.NewEncoder(w)
+		return &exportEncoder{
+			encode: func(r ExportRecord) error { return encoder.Encode(r) },
+			flush:  func() error { return nil },
+		}, nil
+
+	case ExportCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(exportColumns); err != nil {
+			return nil, err
+		}
+		return &exportEncoder{
+			encode: func(r ExportRecord) error {
+				return writer.Write([]string{r.Direction, r.ActorUserID, r.RecipientUserID, r.Decision, strconv.FormatInt(r.UnixTimestamp, 10), r.Time, strconv.FormatBool(r.Expired)})
+			},
+			flush: func() error {
+				writer.Flush()
+				return writer.Error()
+			},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/models"
+	redis_mocks "github.com/endyapina/muzzapp/internal/redis/mocks"
+	db_mocks "github.com/endyapina/muzzapp/internal/repository/mocks"
+)
+
+func TestExploreService_ExportUserData(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		format ExportFormat
+		want   string
+	}{
+		{
+			name:   "json lines",
+			format: ExportJSONLines,
+			want: `{"direction":"made","actor_user_id":"user1","recipient_user_id":"user2","decision":"like","unix_timestamp":1000,"time":"1970-01-01T00:16:40Z","expired":false}
+{"direction":"made","actor_user_id":"user1","recipient_user_id":"user3","decision":"pass","unix_timestamp":1100,"time":"1970-01-01T00:18:20Z","expired":false}
+{"direction":"made","actor_user_id":"user1","recipient_user_id":"user4","decision":"like","unix_timestamp":1200,"time":"1970-01-01T00:20:00Z","expired":false}
+{"direction":"received","actor_user_id":"user2","recipient_user_id":"user1","decision":"like","unix_timestamp":1300,"time":"1970-01-01T00:21:40Z","expired":false}
+{"direction":"made","actor_user_id":"user1","recipient_user_id":"user5","decision":"pass","unix_timestamp":900,"time":"1970-01-01T00:15:00Z","expired":true}
+`,
+		},
+		{
+			name:   "csv",
+			format: ExportCSV,
+			want: `direction,actor_user_id,recipient_user_id,decision,unix_timestamp,time,expired
+made,user1,user2,like,1000,1970-01-01T00:16:40Z,false
+made,user1,user3,pass,1100,1970-01-01T00:18:20Z,false
+made,user1,user4,like,1200,1970-01-01T00:20:00Z,false
+received,user2,user1,like,1300,1970-01-01T00:21:40Z,false
+made,user1,user5,pass,900,1970-01-01T00:15:00Z,true
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			// a full batch of made decisions is followed by another scan after its last recipient
+			mockRepo.EXPECT().ScanUserDecisions(mock.Anything, "user1", false, "", 2).Return([]models.Decision{
+				{ActorUserID: "user1", RecipientUserID: "user2", Liked: true, UnixTimestamp: 1000},
+				{ActorUserID: "user1", RecipientUserID: "user3", Liked: false, UnixTimestamp: 1100},
+			}, nil).Once()
+			mockRepo.EXPECT().ScanUserDecisions(mock.Anything, "user1", false, "user3", 2).Return([]models.Decision{
+				{ActorUserID: "user1", RecipientUserID: "user4", Liked: true, UnixTimestamp: 1200},
+			}, nil).Once()
+			mockRepo.EXPECT().ScanUserDecisions(mock.Anything, "user1", true, "", 2).Return([]models.Decision{
+				{ActorUserID: "user2", RecipientUserID: "user1", Liked: true, UnixTimestamp: 1300},
+			}, nil).Once()
+			mockRepo.EXPECT().ScanUserExpiredPasses(mock.Anything, "user1", false, uint64(0), 2).Return([]models.ExpiredPass{
+				{ID: 7, ActorUserID: "user1", RecipientUserID: "user5", UnixTimestamp: 900, ArchivedAt: 9000},
+			}, nil).Once()
+			mockRepo.EXPECT().ScanUserExpiredPasses(mock.Anything, "user1", true, uint64(0), 2).Return(nil, nil).Once()
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{ExportBatchSize: 2})
+			var out strings.Builder
+			count, err := svc.ExportUserData(ctx, "user1", tt.format, &out)
+			require.NoError(t, err)
+			assert.Equal(t, 5, count)
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func TestExploreService_ExportUserData_ZeroBatchSize(t *testing.T) {
+	mockRepo := db_mocks.NewRepository(t)
+	mockCache := redis_mocks.NewRepository(t)
+
+	// an empty batch ends the scan instead of reading past its last decision
+	mockRepo.EXPECT().ScanUserDecisions(mock.Anything, "user1", mock.Anything, "", 0).Return(nil, nil).Twice()
+	mockRepo.EXPECT().ScanUserExpiredPasses(mock.Anything, "user1", mock.Anything, uint64(0), 0).Return(nil, nil).Twice()
+
+	svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{})
+	var out strings.Builder
+	count, err := svc.ExportUserData(context.Background(), "user1", ExportJSONLines, &out)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/endyapina/muzzapp/internal/models"
+	redis_cache "github.com/endyapina/muzzapp/internal/redis"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// RunOutboxRelay applies pending outbox events to redis until ctx is cancelled.
+//
+// every decision is committed to mysql together with an outbox event describing the
+// cache write it needs. the relay writes the likes cache, taking turns with the rebuilds
+// under the relay lock: it drains the outbox in insertion order every
+// OutboxRelayInterval (or right away when PutDecision nudges it), backing off
+// exponentially while redis keeps failing. events are only marked processed after redis
+// accepted them, which gives at-least-once, eventually-consistent cache updates.
+func (s *ExploreService) RunOutboxRelay(ctx context.Context) {
+	delay := s.config.OutboxRelayInterval
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	purge := time.NewTicker(time.Hour)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-purge.C:
+			s.purge(ctx)
+			continue
+		case <-timer.C:
+		case <-s.relayWake:
+			timer.Stop()
+		}
+
+		relayed, err := s.RelayOutbox(ctx)
+		if err != nil {
+			s.logger.WarnContext(ctx, "outbox relay failed", "retry_in", delay, "error", err)
+			timer.Reset(delay)
+			delay = min(delay*2, s.config.OutboxRelayMaxBackoff)
+			continue
+		}
+
+		if relayed > 0 {
+			s.logger.DebugContext(ctx, "outbox events relayed", "events", relayed)
+		}
+		delay = s.config.OutboxRelayInterval
+		timer.Reset(delay)
+	}
+}
+
+// purge deletes processed outbox events and idempotency keys past their retention.
+func (s *ExploreService) purge(ctx context.Context) {
+	now := time.Now()
+	if _, err := s.repo.PurgeOutbox(ctx, now.Add(-s.config.OutboxRetention).Unix()); err != nil {
+		s.logger.ErrorContext(ctx, "failed to purge outbox", "error", err)
+	}
+	if _, err := s.repo.PurgeIdempotencyKeys(ctx, now.Add(-s.config.IdempotencyKeyTTL).Unix()); err != nil {
+		s.logger.ErrorContext(ctx, "failed to purge idempotency keys", "error", err)
+	}
+}
+
+// RelayOutbox applies pending outbox events to redis in batches until the outbox is
+// drained and returns the number of events applied.
+func (s *ExploreService) RelayOutbox(ctx context.Context) (int, error) {
+	var total int
+	for {
+		count, err := s.repo.ProcessOutbox(ctx, s.config.OutboxBatchSize, func(events []models.OutboxEvent) error {
+			// only batches that carry events are traced, idle polls would drown them
+			ctx, span := startSpan(ctx, "RelayOutbox", attribute.Int("outbox.events", len(events)))
+			defer span.End()
+
+			if err := s.cache.ApplyMutations(ctx, toMutations(events)); err != nil {
+				failSpan(span, err)
+				return err
+			}
+			return nil
+		})
+		total += count
+		if err != nil {
+			return total, err
+		}
+		if count == 0 || count < s.config.OutboxBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// wakeRelay asks the relay to run now. it never blocks: a pending nudge already
+// covers the events written since.
+func (s *ExploreService) wakeRelay() {
+	select {
+	case s.relayWake <- struct{}{}:
+	default:
+	}
+}
+
+// toMutations maps outbox events onto cache writes. outbox ops share their names with
+// the cache mutation ops.
+func toMutations(events []models.OutboxEvent) []redis_cache.Mutation {
+	mutations := make([]redis_cache.Mutation, 0, len(events))
+	for _, e := range events {
+		mutations = append(mutations, redis_cache.Mutation{
+			Op:          redis_cache.MutationOp(e.Op),
+			RecipientID: e.RecipientUserID,
+			ActorID:     e.ActorUserID,
+			Timestamp:   e.UnixTimestamp,
+		})
+	}
+	return mutations
+}
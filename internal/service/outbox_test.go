@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/redis"
+	redis_mocks "github.com/endyapina/muzzapp/internal/redis/mocks"
+	db_mocks "github.com/endyapina/muzzapp/internal/repository/mocks"
+)
+
+func TestExploreService_RelayOutbox(t *testing.T) {
+	ctx := context.Background()
+
+	batches := [][]models.OutboxEvent{
+		{
+			{ID: 1, Op: models.OutboxAddLike, RecipientUserID: "user2", ActorUserID: "user1", UnixTimestamp: 1000},
+			{ID: 2, Op: models.OutboxRemoveLike, RecipientUserID: "user3", ActorUserID: "user1", UnixTimestamp: 1001},
+		},
+		{
+			{ID: 3, Op: models.OutboxAddLike, RecipientUserID: "user3", ActorUserID: "user4", UnixTimestamp: 1002},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		mockApplyErr error
+		wantApplied  [][]redis.Mutation
+		wantCount    int
+		wantErr      bool
+	}{
+		{
+			name: "success - drains every batch",
+			wantApplied: [][]redis.Mutation{
+				{
+					{Op: redis.MutationAddLike, RecipientID: "user2", ActorID: "user1", Timestamp: 1000},
+					{Op: redis.MutationRemoveLike, RecipientID: "user3", ActorID: "user1", Timestamp: 1001},
+				},
+				{
+					{Op: redis.MutationAddLike, RecipientID: "user3", ActorID: "user4", Timestamp: 1002},
+				},
+			},
+			wantCount: 3,
+		},
+		{
+			name:         "cache error - stops and keeps events pending",
+			mockApplyErr: errors.New("redis error"),
+			wantApplied: [][]redis.Mutation{
+				{
+					{Op: redis.MutationAddLike, RecipientID: "user2", ActorID: "user1", Timestamp: 1000},
+					{Op: redis.MutationRemoveLike, RecipientID: "user3", ActorID: "user1", Timestamp: 1001},
+				},
+			},
+			wantCount: 0,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			// ProcessOutbox hands out the batches in order, marking a batch
+			// processed only when the apply callback succeeds
+			var call int
+			mockRepo.EXPECT().
+				ProcessOutbox(mock.Anything, 2, mock.Anything).
+				RunAndReturn(func(_ context.Context, _ int, apply func([]models.OutboxEvent) error) (int, error) {
+					events := batches[call]
+					call++
+					if err := apply(events); err != nil {
+						return 0, err
+					}
+					return len(events), nil
+				})
+
+			var applied [][]redis.Mutation
+			mockCache.EXPECT().
+				ApplyMutations(mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, mutations []redis.Mutation) error {
+					applied = append(applied, mutations)
+					return tt.mockApplyErr
+				})
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{OutboxBatchSize: 2})
+			count, err := svc.RelayOutbox(ctx)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCount, count)
+			assert.Equal(t, tt.wantApplied, applied)
+		})
+	}
+}
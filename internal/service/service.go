@@ -2,84 +2,443 @@ package service
 
 import (
 	"context"
-	"time"
+	"errors"
+	"log/slog"
+	"strconv"
+	"sync"
 
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/metrics"
+	"github.com/endyapina/muzzapp/internal/pagination"
 	redis_cache "github.com/endyapina/muzzapp/internal/redis"
 	"github.com/endyapina/muzzapp/internal/repository"
 	pb "github.com/endyapina/muzzapp/proto/gen/muzzapp/proto"
 	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// ErrSelfDecision is returned when a user likes or passes themselves.
+var ErrSelfDecision = errors.New("actor and recipient must be different users")
+
 type ExploreService struct {
-	repo  repository.Repository
-	cache redis_cache.Repository
+	repo    repository.Repository
+	cache   redis_cache.Repository
+	config  *config.AppConfig
+	cursors *pagination.Codec
+	logger  *slog.Logger
+
+	// relayWake nudges the outbox relay to run ahead of its next tick
+	relayWake chan struct{}
+
+	// repopulates queues the recipients whose sets RunRepopulator rebuilds after a cache
+	// miss, repopulating holds those queued or being rebuilt
+	repopulates  chan string
+	repopulating map[string]struct{}
+	repopulateMu sync.Mutex
+
+	// watches is cancelled on shutdown to end every WatchLikedYou stream
+	watches     context.Context
+	stopWatches context.CancelFunc
 }
 
-func New(repo repository.Repository, cache redis_cache.Repository) *ExploreService {
-	return &ExploreService{
-		repo:  repo,
-		cache: cache,
+func New(repo repository.Repository, cache redis_cache.Repository, config *config.AppConfig) (*ExploreService, error) {
+	cursors, err := pagination.NewCodec(config.PaginationSecret, config.PaginationTokenTTL)
+	if err != nil {
+		return nil, err
 	}
+	watches, stopWatches := context.WithCancel(context.Background())
+	return &ExploreService{
+		repo:         repo,
+		cache:        cache,
+		config:       config,
+		cursors:      cursors,
+		logger:       slog.Default().With("component", "service"),
+		relayWake:    make(chan struct{}, 1),
+		repopulates:  make(chan string, repopulateQueueSize),
+		repopulating: make(map[string]struct{}),
+		watches:      watches,
+		stopWatches:  stopWatches,
+	}, nil
 }
 
-// PutDecision: business logic with caching and mutual likes
-func (s *ExploreService) PutDecision(ctx context.Context, actorID, recipientID string, liked bool) (bool, error) {
-	if err := s.repo.UpsertDecision(ctx, actorID, recipientID, liked); err != nil {
+// PutDecision: business logic with caching and mutual likes.
+//
+// the decision, its outbox event and the mutual check are committed in one transaction
+// by the repository. the cache isn't written here: the outbox relay mirrors the decision
+// into redis. a retry repeating an idempotency key gets the outcome of the first call and
+// records nothing. decisions that add or withdraw a like are published to the watchers of
+// both users.
+func (s *ExploreService) PutDecision(ctx context.Context, actorID, recipientID string, liked bool, idempotencyKey string) (bool, error) {
+	ctx, span := startSpan(ctx, "PutDecision", attribute.String("actor.id", actorID), attribute.String("recipient.id", recipientID), attribute.Bool("liked", liked))
+	defer span.End()
+
+	if actorID == recipientID {
+		return false, ErrSelfDecision
+	}
+
+	outcome, err := s.repo.RecordDecision(ctx, actorID, recipientID, liked, idempotencyKey)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to record decision",
+			"actor_id", actorID, "recipient_id", recipientID, "liked", liked, "error", err)
 		return false, err
 	}
+	s.logger.DebugContext(ctx, "decision recorded",
+		"actor_id", actorID, "recipient_id", recipientID, "liked", liked, "mutual", outcome.Mutual)
+	s.wakeRelay()
+	s.publish(ctx, likeEvents(actorID, recipientID, liked, outcome),
+		"actor_id", actorID, "recipient_id", recipientID)
+
+	span.SetAttributes(attribute.Bool("mutual", outcome.Mutual))
+	return outcome.Mutual, nil
+}
+
+// PutDecisions records a batch of decisions of one actor in a single transaction and
+// returns for each of them whether the two users like each other after it. the relay is
+// woken once for the whole batch, which then reaches redis in a single pipeline.
+func (s *ExploreService) PutDecisions(ctx context.Context, actorID string, decisions []repository.BatchDecision) ([]bool, error) {
+	ctx, span := startSpan(ctx, "PutDecisions", attribute.String("actor.id", actorID), attribute.Int("decisions", len(decisions)))
+	defer span.End()
+
+	for _, d := range decisions {
+		if d.RecipientID == actorID {
+			return nil, ErrSelfDecision
+		}
+	}
+
+	outcomes, err := s.repo.RecordDecisions(ctx, actorID, decisions)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to record decisions", "actor_id", actorID, "decisions", len(decisions), "error", err)
+		return nil, err
+	}
+	s.logger.DebugContext(ctx, "decisions recorded", "actor_id", actorID, "decisions", len(decisions))
+	s.wakeRelay()
+
+	mutual := make([]bool, len(outcomes))
+	var events []redis_cache.LikeEvent
+	for i, outcome := range outcomes {
+		mutual[i] = outcome.Mutual
+		events = append(events, likeEvents(actorID, decisions[i].RecipientID, decisions[i].Liked, outcome)...)
+	}
+	s.publish(ctx, events, "actor_id", actorID, "decisions", len(decisions))
+
+	return mutual, nil
+}
 
-	if liked {
-		s.cache.AddLike(ctx, recipientID, actorID, time.Now().Unix())
-	} else {
-		s.cache.RemoveLike(ctx, recipientID, actorID)
+// UndoDecision reverts the most recent decision of an actor and restores the previous one,
+// see repository.UndoDecision. the recipient's watchers are told about a like that is
+// withdrawn or comes back.
+func (s *ExploreService) UndoDecision(ctx context.Context, actorID string) (repository.Undo, error) {
+	ctx, span := startSpan(ctx, "UndoDecision", attribute.String("actor.id", actorID))
+	defer span.End()
+
+	undo, err := s.repo.UndoDecision(ctx, actorID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "failed to undo decision", "actor_id", actorID, "error", err)
+		return repository.Undo{}, err
 	}
+	recipientID := undo.Undone.RecipientUserID
+	liked := undo.Restored != nil && undo.Restored.Liked
+	s.logger.DebugContext(ctx, "decision undone",
+		"actor_id", actorID, "recipient_id", recipientID, "liked", undo.Undone.Liked, "restored", undo.Restored != nil)
+	s.wakeRelay()
+	s.publish(ctx, likeEvents(actorID, recipientID, liked, undo.Outcome),
+		"actor_id", actorID, "recipient_id", recipientID)
 
-	mutual, err := s.repo.CheckMutualLike(ctx, actorID, recipientID)
-	return mutual, err
+	span.SetAttributes(attribute.String("recipient.id", recipientID), attribute.Bool("mutual", undo.Mutual))
+	return undo, nil
 }
 
+// CountLikedYou counts the likes of a recipient from redis, falling back to mysql when
+// redis is unreachable or holds no set for the recipient.
+//
+// redis never stores empty sorted sets, so a zero count can't be told apart from a
+// missing key and always goes to the database. the database answer is written back to
+// redis so the next read is served from the cache again.
 func (s *ExploreService) CountLikedYou(ctx context.Context, recipientID string) (uint64, error) {
+	ctx, span := startSpan(ctx, "CountLikedYou", attribute.String("recipient.id", recipientID))
+	defer span.End()
+
 	count, err := s.cache.CountLikes(ctx, recipientID)
-	return uint64(count), err
+	if err == nil && count > 0 {
+		readFrom(span, "CountLikedYou", metrics.SourceRedis)
+		return uint64(count), nil
+	}
+	cacheUp := !s.cacheDown(ctx, "CountLikedYou", err)
+
+	dbCount, err := s.repo.CountLikes(ctx, recipientID)
+	if err != nil {
+		return 0, err
+	}
+	readFrom(span, "CountLikedYou", metrics.SourceMySQL)
+
+	if cacheUp && dbCount > 0 {
+		s.repopulate(ctx, recipientID)
+	}
+	return dbCount, nil
 }
 
-func (s *ExploreService) ListLikedYou(ctx context.Context, recipientID string, paginationToken string) ([]*pb.ListLikedYouResponse_Liker, string, error) {
-	entries, nextToken, err := s.cache.GetLikers(ctx, recipientID, paginationToken)
-	if err != nil && err != redis.Nil {
+// LikesQuery shapes a likes listing. an empty Direction follows the pagination token and
+// is oldest first without one, a zero Since or Until leaves that side of the window open
+// and a zero PageSize uses the configured page size.
+type LikesQuery struct {
+	Direction pagination.Direction
+	Since     int64
+	Until     int64
+	PageSize  int
+}
+
+// ListLikedYou lists the likers of a recipient from redis, falling back to mysql when
+// redis is unreachable or holds no set for the recipient (an empty first page). both
+// backends understand the same cursors, so a listing can switch backends between pages.
+//
+// a time window may legitimately hold no likes, so an empty windowed first page only
+// counts as a miss when the recipient has no likes cached at all.
+func (s *ExploreService) ListLikedYou(ctx context.Context, recipientID string, paginationToken string, query LikesQuery) ([]*pb.ListLikedYouResponse_Liker, string, error) {
+	ctx, span := startSpan(ctx, "ListLikedYou", attribute.String("recipient.id", recipientID))
+	defer span.End()
+
+	page, err := s.likesPage(paginationToken, recipientID, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, more, err := s.cache.GetLikers(ctx, recipientID, page)
+	hit := err == nil && (len(entries) > 0 || page.After != nil)
+	if err == nil && !hit && page.Windowed() {
+		var count int64
+		count, err = s.cache.CountLikes(ctx, recipientID)
+		hit = err == nil && count > 0
+	}
+	if hit {
+		readFrom(span, "ListLikedYou", metrics.SourceRedis)
+		likers := fromZ(entries)
+		return likers, s.nextToken(recipientID, page, likers, more), nil
+	}
+	cacheUp := !s.cacheDown(ctx, "ListLikedYou", err)
+
+	dbLikers, more, err := s.repo.GetLikers(ctx, recipientID, page)
+	if err != nil {
 		return nil, "", err
 	}
+	readFrom(span, "ListLikedYou", metrics.SourceMySQL)
+
+	if cacheUp && len(dbLikers) > 0 {
+		s.repopulate(ctx, recipientID)
+	}
 
 	var likers []*pb.ListLikedYouResponse_Liker
-	for _, e := range entries {
-		likers = append(likers, &pb.ListLikedYouResponse_Liker{
-			ActorId:       e.Member.(string),
-			UnixTimestamp: uint64(int64(e.Score)),
+	for i := range dbLikers {
+		likers = append(likers, &dbLikers[i])
+	}
+	return likers, s.nextToken(recipientID, page, likers, more), nil
+}
+
+// cacheDown logs a failed cache read, the caller falls back to the database. a missing
+// key (redis.Nil) is a plain miss and not logged.
+func (s *ExploreService) cacheDown(ctx context.Context, method string, err error) bool {
+	if err == nil || err == redis.Nil {
+		return false
+	}
+	s.logger.WarnContext(ctx, "redis read failed, falling back to mysql", "method", method, "error", err)
+	return true
+}
+
+// ListNewLikedYou lists the likers the recipient hasn't liked back from the "new_liked"
+// sorted set, so every page is filled up to the page size. it falls back to mysql when
+// redis is unreachable or the cache of the recipient is cold.
+//
+// an empty "new_liked" set is common (every like was reciprocated), so an empty first page
+// only counts as a miss when the "liked" set, which is always maintained alongside it, is
+// missing too.
+func (s *ExploreService) ListNewLikedYou(ctx context.Context, recipientID string, paginationToken string, query LikesQuery) ([]*pb.ListLikedYouResponse_Liker, string, error) {
+	ctx, span := startSpan(ctx, "ListNewLikedYou", attribute.String("recipient.id", recipientID))
+	defer span.End()
+
+	page, err := s.likesPage(paginationToken, recipientID, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries, more, err := s.cache.GetNewLikers(ctx, recipientID, page)
+	hit := err == nil && (len(entries) > 0 || page.After != nil)
+	if err == nil && !hit {
+		var count int64
+		count, err = s.cache.CountLikes(ctx, recipientID)
+		hit = err == nil && count > 0
+	}
+	if hit {
+		readFrom(span, "ListNewLikedYou", metrics.SourceRedis)
+		likers := fromZ(entries)
+		return likers, s.nextToken(recipientID, page, likers, more), nil
+	}
+	cacheUp := !s.cacheDown(ctx, "ListNewLikedYou", err)
+
+	dbLikers, more, err := s.repo.GetNewLikers(ctx, recipientID, page)
+	if err != nil {
+		return nil, "", err
+	}
+	readFrom(span, "ListNewLikedYou", metrics.SourceMySQL)
+
+	if cacheUp && len(dbLikers) > 0 {
+		s.repopulate(ctx, recipientID)
+	}
+
+	var likers []*pb.ListLikedYouResponse_Liker
+	for i := range dbLikers {
+		likers = append(likers, &dbLikers[i])
+	}
+	return likers, s.nextToken(recipientID, page, likers, more), nil
+}
+
+func (s *ExploreService) ListMatches(ctx context.Context, userID string, paginationToken string) ([]*pb.ListMatchesResponse_Match, string, error) {
+	ctx, span := startSpan(ctx, "ListMatches", attribute.String("user.id", userID))
+	defer span.End()
+
+	owner := matchesOwner(userID)
+	after, err := s.cursors.Decode(paginationToken, owner)
+	if err != nil {
+		return nil, "", err
+	}
+
+	results, more, err := s.repo.ListMatches(ctx, userID, after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var matches []*pb.ListMatchesResponse_Match
+	for i := range results {
+		matches = append(matches, &results[i])
+	}
+
+	var nextToken string
+	if more {
+		last := matches[len(matches)-1]
+		nextToken = s.cursors.Encode(pagination.Cursor{
+			Timestamp: int64(last.UnixTimestamp),
+			ID:        last.UserId,
+			Direction: pagination.Ascending,
+			Owner:     owner,
 		})
 	}
-	return likers, nextToken, nil
+	return matches, nextToken, nil
+}
+
+// matchesOwner is the cursor owner of a matches listing, so a likes token of the same
+// user can't be replayed against it.
+func matchesOwner(userID string) string {
+	return "matches:" + userID
 }
 
-func (s *ExploreService) ListNewLikedYou(ctx context.Context, recipientID string, paginationToken string) ([]*pb.ListLikedYouResponse_Liker, string, error) {
-	entries, nextToken, err := s.cache.GetLikers(ctx, recipientID, paginationToken)
-	if err != nil && err != redis.Nil {
+func (s *ExploreService) CountMatches(ctx context.Context, userID string) (uint64, error) {
+	ctx, span := startSpan(ctx, "CountMatches", attribute.String("user.id", userID))
+	defer span.End()
+
+	return s.repo.CountMatches(ctx, userID)
+}
+
+// GetDecisionHistory lists the decisions of an actor, newest first, optionally only those
+// about a single recipient. tokens are bound to both users, so a token of one listing
+// can't be replayed against another.
+func (s *ExploreService) GetDecisionHistory(ctx context.Context, actorID, recipientID string, paginationToken string) ([]*pb.GetDecisionHistoryResponse_Event, string, error) {
+	ctx, span := startSpan(ctx, "GetDecisionHistory", attribute.String("actor.id", actorID), attribute.String("recipient.id", recipientID))
+	defer span.End()
+
+	owner := historyOwner(actorID, recipientID)
+	after, err := s.cursors.Decode(paginationToken, owner)
+	if err != nil {
+		return nil, "", err
+	}
+
+	results, more, err := s.repo.GetDecisionHistory(ctx, actorID, recipientID, after)
+	if err != nil {
 		return nil, "", err
 	}
 
+	events := make([]*pb.GetDecisionHistoryResponse_Event, 0, len(results))
+	for _, e := range results {
+		events = append(events, &pb.GetDecisionHistoryResponse_Event{
+			ActorUserId:     e.ActorUserID,
+			RecipientUserId: e.RecipientUserID,
+			LikedRecipient:  e.Liked,
+			Source:          e.Source,
+			RequestId:       e.RequestID,
+			UnixTimestamp:   uint64(e.UnixTimestamp),
+		})
+	}
+
+	var nextToken string
+	if more && len(results) > 0 {
+		last := results[len(results)-1]
+		nextToken = s.cursors.Encode(pagination.Cursor{
+			Timestamp: last.UnixTimestamp,
+			ID:        strconv.FormatUint(last.ID, 10),
+			Direction: pagination.Descending,
+			Owner:     owner,
+		})
+	}
+	return events, nextToken, nil
+}
+
+// historyOwner is the cursor owner of a decision history listing.
+func historyOwner(actorID, recipientID string) string {
+	return "history:" + actorID + ":" + recipientID
+}
+
+// likesPage decodes the pagination token of a likes listing into the page it asks for.
+// tokens don't carry a page size, so every request picks its own.
+func (s *ExploreService) likesPage(paginationToken, recipientID string, query LikesQuery) (pagination.Page, error) {
+	after, err := s.cursors.Decode(paginationToken, likesOwner(recipientID))
+	if err != nil {
+		return pagination.Page{}, err
+	}
+	page, err := pagination.Resolve(after, query.Direction, query.Since, query.Until)
+	if err != nil {
+		return pagination.Page{}, err
+	}
+	page.Size = s.pageSize(query.PageSize)
+	return page, nil
+}
+
+// pageSize returns the page size a client asked for, capped at the configured maximum.
+func (s *ExploreService) pageSize(requested int) int {
+	if requested <= 0 {
+		return int(s.config.PaginationSize)
+	}
+	if max := int(s.config.PaginationMaxSize); max > 0 && requested > max {
+		return max
+	}
+	return requested
+}
+
+// nextToken returns the pagination token of the page following likers, or an empty token
+// on the last page. the token carries the page's direction, so the listing keeps its order.
+func (s *ExploreService) nextToken(recipientID string, page pagination.Page, likers []*pb.ListLikedYouResponse_Liker, more bool) string {
+	if !more || len(likers) == 0 {
+		return ""
+	}
+	last := likers[len(likers)-1]
+	return s.cursors.Encode(pagination.Cursor{
+		Timestamp: int64(last.UnixTimestamp),
+		ID:        last.ActorId,
+		Direction: page.Direction,
+		Owner:     likesOwner(recipientID),
+	})
+}
+
+// likesOwner is the cursor owner of the likes listings of a recipient. ListLikedYou and
+// ListNewLikedYou share it: both page through likes by (timestamp, actor id).
+func likesOwner(recipientID string) string {
+	return "likes:" + recipientID
+}
+
+// fromZ converts sorted set entries into likers.
+func fromZ(entries []redis_cache.Z) []*pb.ListLikedYouResponse_Liker {
 	var likers []*pb.ListLikedYouResponse_Liker
 	for _, e := range entries {
-		actorID := e.Member.(string)
-		likedBack, err := s.repo.HasRecipientLikedActor(ctx, recipientID, actorID)
-		if err != nil {
-			return nil, "", err
-		}
-		if likedBack {
-			continue
-		}
 		likers = append(likers, &pb.ListLikedYouResponse_Liker{
-			ActorId:       actorID,
+			ActorId:       e.Member.(string),
 			UnixTimestamp: uint64(int64(e.Score)),
 		})
 	}
-
-	return likers, nextToken, nil
+	return likers
 }
@@ -7,9 +7,14 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/pagination"
 	"github.com/endyapina/muzzapp/internal/redis"
 	redis_mocks "github.com/endyapina/muzzapp/internal/redis/mocks"
+	"github.com/endyapina/muzzapp/internal/repository"
 	db_mocks "github.com/endyapina/muzzapp/internal/repository/mocks"
 )
 
@@ -19,9 +24,9 @@ func TestExploreService_PutDecision(t *testing.T) {
 		actorID       string
 		recipientID   string
 		liked         bool
-		mockUpsertErr error
-		mockMutual    bool
-		mockMutualErr error
+		mockOutcome   repository.Outcome
+		mockRecordErr error
+		wantEvents    []redis.LikeEventType
 		wantMutual    bool
 		wantErr       bool
 	}{
@@ -30,25 +35,43 @@ func TestExploreService_PutDecision(t *testing.T) {
 			actorID:     "user1",
 			recipientID: "user2",
 			liked:       true,
-			mockMutual:  true,
+			mockOutcome: repository.Outcome{Mutual: true, Changed: true},
+			wantEvents:  []redis.LikeEventType{redis.LikeEventLiked, redis.LikeEventMatched, redis.LikeEventMatched},
 			wantMutual:  true,
 			wantErr:     false,
 		},
 		{
-			name:          "failure - repo upsert error",
+			name:        "success - repeated like publishes nothing",
+			actorID:     "user1",
+			recipientID: "user2",
+			liked:       true,
+			mockOutcome: repository.Outcome{Mutual: true},
+			wantMutual:  true,
+		},
+		{
+			name:        "success - pass",
+			actorID:     "user1",
+			recipientID: "user2",
+			liked:       false,
+			mockOutcome: repository.Outcome{Changed: true},
+			wantEvents:  []redis.LikeEventType{redis.LikeEventUnliked},
+			wantMutual:  false,
+			wantErr:     false,
+		},
+		{
+			name:          "failure - repo record error",
 			actorID:       "user1",
 			recipientID:   "user2",
 			liked:         true,
-			mockUpsertErr: errors.New("db error"),
+			mockRecordErr: errors.New("db error"),
 			wantErr:       true,
 		},
 		{
-			name:          "failure - mutual check error",
-			actorID:       "user1",
-			recipientID:   "user2",
-			liked:         false,
-			mockMutualErr: errors.New("db error"),
-			wantErr:       true,
+			name:        "failure - self decision",
+			actorID:     "user1",
+			recipientID: "user1",
+			liked:       true,
+			wantErr:     true,
 		},
 	}
 
@@ -59,31 +82,28 @@ func TestExploreService_PutDecision(t *testing.T) {
 			mockRepo := db_mocks.NewRepository(t)
 			mockCache := redis_mocks.NewRepository(t)
 
-			mockRepo.EXPECT().
-				UpsertDecision(ctx, tt.actorID, tt.recipientID, tt.liked).
-				Return(tt.mockUpsertErr)
-
-			if tt.mockUpsertErr == nil {
+			if tt.actorID != tt.recipientID {
 				mockRepo.EXPECT().
-					CheckMutualLike(ctx, tt.actorID, tt.recipientID).
-					Return(tt.mockMutual, tt.mockMutualErr)
+					RecordDecision(mock.Anything, tt.actorID, tt.recipientID, tt.liked, "key1").
+					Return(tt.mockOutcome, tt.mockRecordErr)
 			}
 
-			if tt.mockUpsertErr == nil {
-				if tt.liked {
-					mockCache.EXPECT().
-						AddLike(ctx, tt.recipientID, tt.actorID, mock.AnythingOfType("int64")).
-						Return(nil)
-				} else {
-					mockCache.EXPECT().
-						RemoveLike(ctx, tt.recipientID, tt.actorID).
-						Return(nil)
-				}
+			if len(tt.wantEvents) > 0 {
+				mockCache.EXPECT().
+					PublishLikeEvents(mock.Anything, mock.MatchedBy(func(events []redis.LikeEvent) bool {
+						types := make([]redis.LikeEventType, len(events))
+						for i, e := range events {
+							types[i] = e.Type
+						}
+						return assert.ObjectsAreEqual(tt.wantEvents, types)
+					})).
+					Return(nil).
+					Once()
 			}
 
-			svc := New(mockRepo, mockCache)
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{})
 
-			gotMutual, err := svc.PutDecision(ctx, tt.actorID, tt.recipientID, tt.liked)
+			gotMutual, err := svc.PutDecision(ctx, tt.actorID, tt.recipientID, tt.liked, "key1")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -95,6 +115,186 @@ func TestExploreService_PutDecision(t *testing.T) {
 	}
 }
 
+func TestExploreService_PutDecisions(t *testing.T) {
+	decisions := []repository.BatchDecision{
+		{RecipientID: "user2", Liked: true},
+		{RecipientID: "user3", Liked: false},
+	}
+
+	tests := []struct {
+		name          string
+		decisions     []repository.BatchDecision
+		mockOutcomes  []repository.Outcome
+		mockRecordErr error
+		wantMutual    []bool
+		wantErr       error
+	}{
+		{
+			name:         "success",
+			decisions:    decisions,
+			mockOutcomes: []repository.Outcome{{Mutual: true, Changed: true}, {}},
+			wantMutual:   []bool{true, false},
+		},
+		{
+			name:          "failure - repo record error",
+			decisions:     decisions,
+			mockRecordErr: errors.New("db error"),
+			wantErr:       errors.New("db error"),
+		},
+		{
+			name:      "failure - self decision in the batch",
+			decisions: append([]repository.BatchDecision{{RecipientID: "user1", Liked: true}}, decisions...),
+			wantErr:   ErrSelfDecision,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			if tt.wantErr != ErrSelfDecision {
+				mockRepo.EXPECT().
+					RecordDecisions(mock.Anything, "user1", tt.decisions).
+					Return(tt.mockOutcomes, tt.mockRecordErr).
+					Once()
+			}
+			if tt.wantErr == nil {
+				mockCache.EXPECT().
+					PublishLikeEvents(mock.Anything, mock.Anything).
+					Return(nil).
+					Once()
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{})
+			gotMutual, err := svc.PutDecisions(context.Background(), "user1", tt.decisions)
+
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMutual, gotMutual)
+		})
+	}
+}
+
+func TestExploreService_UndoDecision(t *testing.T) {
+	ctx := context.Background()
+	restored := &models.Decision{ActorUserID: "user1", RecipientUserID: "user2", Liked: true, UnixTimestamp: 1000}
+
+	tests := []struct {
+		name       string
+		mockUndo   repository.Undo
+		mockErr    error
+		wantEvents []redis.LikeEventType
+		wantErr    error
+	}{
+		{
+			name: "pass undone, like and match restored",
+			mockUndo: repository.Undo{
+				Undone:   models.DecisionEvent{RecipientUserID: "user2", Liked: false},
+				Restored: restored,
+				Outcome:  repository.Outcome{Mutual: true, Changed: true},
+			},
+			wantEvents: []redis.LikeEventType{redis.LikeEventLiked, redis.LikeEventMatched, redis.LikeEventMatched},
+		},
+		{
+			name: "first like undone",
+			mockUndo: repository.Undo{
+				Undone:  models.DecisionEvent{RecipientUserID: "user2", Liked: true},
+				Outcome: repository.Outcome{Changed: true},
+			},
+			wantEvents: []redis.LikeEventType{redis.LikeEventUnliked},
+		},
+		{
+			name: "first pass undone",
+			mockUndo: repository.Undo{
+				Undone: models.DecisionEvent{RecipientUserID: "user2", Liked: false},
+			},
+		},
+		{
+			name:    "too late",
+			mockErr: repository.ErrUndoExpired,
+			wantErr: repository.ErrUndoExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			mockRepo.EXPECT().UndoDecision(mock.Anything, "user1").Return(tt.mockUndo, tt.mockErr).Once()
+			if len(tt.wantEvents) > 0 {
+				mockCache.EXPECT().
+					PublishLikeEvents(mock.Anything, mock.MatchedBy(func(events []redis.LikeEvent) bool {
+						types := make([]redis.LikeEventType, len(events))
+						for i, e := range events {
+							types[i] = e.Type
+						}
+						return assert.ObjectsAreEqual(tt.wantEvents, types) && events[0].RecipientID == "user2"
+					})).
+					Return(nil).
+					Once()
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{})
+			undo, err := svc.UndoDecision(ctx, "user1")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.mockUndo, undo)
+		})
+	}
+}
+
+// testSecret signs the pagination tokens in the tests
+const testSecret = "secret"
+
+// newTestService creates an explore service on the given mocks.
+func newTestService(t *testing.T, repo repository.Repository, cache redis.Repository, cfg *config.AppConfig) *ExploreService {
+	t.Helper()
+	svc, err := New(repo, cache, cfg)
+	require.NoError(t, err)
+	return svc
+}
+
+// testCodec returns the codec signing the pagination tokens in the tests.
+func testCodec() *pagination.Codec {
+	codec, err := pagination.NewCodec(testSecret, 0)
+	if err != nil {
+		panic(err)
+	}
+	return codec
+}
+
+// testToken returns a pagination token pointing at the given liker.
+func testToken(owner string, ts int64, id string) string {
+	return testTokenIn(pagination.Ascending, owner, ts, id)
+}
+
+// testTokenIn signs a pagination token walking in direction.
+func testTokenIn(direction pagination.Direction, owner string, ts int64, id string) string {
+	return testCodec().Encode(pagination.Cursor{
+		Timestamp: ts,
+		ID:        id,
+		Direction: direction,
+		Owner:     owner,
+	})
+}
+
+// decodeToken decodes a pagination token issued in a test, nil for the last page.
+func decodeToken(t *testing.T, token, owner string) *pagination.Cursor {
+	t.Helper()
+	cursor, err := testCodec().Decode(token, owner)
+	require.NoError(t, err)
+	return cursor
+}
+
 func TestExploreService_ListLikedYou(t *testing.T) {
 	ctx := context.Background()
 
@@ -102,12 +302,23 @@ func TestExploreService_ListLikedYou(t *testing.T) {
 		name            string
 		recipientID     string
 		paginationToken string
+		query           LikesQuery
 		mockCacheData   []redis.Z
-		mockCacheNext   string
+		mockCacheMore   bool
 		mockCacheErr    error
+		mockCount       int64
+		mockDBData      []repository.Liker
+		mockDBMore      bool
+		mockDBErr       error
+		wantPageSize    int
+		wantCache       bool
+		wantCount       bool
+		wantDB          bool
+		wantRepopulate  bool
 		wantLikers      []string
-		wantNextToken   string
-		wantErr         bool
+		wantNextAfter   string
+		wantDirection   pagination.Direction
+		wantErr         error
 	}{
 		{
 			name:            "success",
@@ -117,17 +328,128 @@ func TestExploreService_ListLikedYou(t *testing.T) {
 				{Member: "user1", Score: 1000},
 				{Member: "user3", Score: 1100},
 			},
-			mockCacheNext: "token_from_cache",
+			mockCacheMore: true,
+			wantCache:     true,
 			wantLikers:    []string{"user1", "user3"},
-			wantNextToken: "token_from_cache",
-			wantErr:       false,
+			wantNextAfter: "user3",
+		},
+		{
+			name:            "last page from cache",
+			recipientID:     "user2",
+			paginationToken: testToken(likesOwner("user2"), 1100, "user3"),
+			wantCache:       true,
+			wantLikers:      []string{},
+			wantNextAfter:   "",
 		},
 		{
-			name:            "cache error",
+			name:            "cache miss - falls back to db and repopulates",
 			recipientID:     "user2",
 			paginationToken: "",
+			mockDBData: []repository.Liker{
+				{ActorId: "user1", UnixTimestamp: 1000},
+			},
+			mockDBMore:     true,
+			wantCache:      true,
+			wantDB:         true,
+			wantRepopulate: true,
+			wantLikers:     []string{"user1"},
+			wantNextAfter:  "user1",
+		},
+		{
+			name:            "cache error - falls back to db with the same cursor",
+			recipientID:     "user2",
+			paginationToken: testToken(likesOwner("user2"), 1000, "user1"),
 			mockCacheErr:    errors.New("redis error"),
-			wantErr:         true,
+			mockDBData: []repository.Liker{
+				{ActorId: "user3", UnixTimestamp: 1100},
+			},
+			wantCache:     true,
+			wantDB:        true,
+			wantLikers:    []string{"user3"},
+			wantNextAfter: "",
+		},
+		{
+			name:            "cache and db error",
+			recipientID:     "user2",
+			paginationToken: "",
+			mockCacheErr:    errors.New("redis error"),
+			mockDBErr:       errors.New("db error"),
+			wantCache:       true,
+			wantDB:          true,
+			wantErr:         errors.New("db error"),
+		},
+		{
+			name:        "newest first",
+			recipientID: "user2",
+			query:       LikesQuery{Direction: pagination.Descending},
+			mockCacheData: []redis.Z{
+				{Member: "user3", Score: 1100},
+				{Member: "user1", Score: 1000},
+			},
+			mockCacheMore: true,
+			wantCache:     true,
+			wantLikers:    []string{"user3", "user1"},
+			wantNextAfter: "user1",
+			wantDirection: pagination.Descending,
+		},
+		{
+			name:            "token keeps its order without one in the request",
+			recipientID:     "user2",
+			paginationToken: testTokenIn(pagination.Descending, likesOwner("user2"), 1000, "user1"),
+			mockCacheData: []redis.Z{
+				{Member: "user0", Score: 900},
+			},
+			mockCacheMore: true,
+			wantCache:     true,
+			wantLikers:    []string{"user0"},
+			wantNextAfter: "user0",
+			wantDirection: pagination.Descending,
+		},
+		{
+			name:          "page size is capped",
+			recipientID:   "user2",
+			query:         LikesQuery{PageSize: 500},
+			mockCacheData: []redis.Z{{Member: "user1", Score: 1000}},
+			wantPageSize:  100,
+			wantCache:     true,
+			wantLikers:    []string{"user1"},
+		},
+		{
+			name:            "token issued for the other order",
+			recipientID:     "user2",
+			paginationToken: testToken(likesOwner("user2"), 1000, "user1"),
+			query:           LikesQuery{Direction: pagination.Descending},
+			wantErr:         pagination.ErrDirectionMismatch,
+		},
+		{
+			name:        "empty window of a cached recipient",
+			recipientID: "user2",
+			query:       LikesQuery{Since: 5000},
+			mockCount:   3,
+			wantCache:   true,
+			wantCount:   true,
+			wantLikers:  []string{},
+		},
+		{
+			name:        "empty window of a cold recipient - falls back to db",
+			recipientID: "user2",
+			query:       LikesQuery{Since: 5000},
+			wantCache:   true,
+			wantCount:   true,
+			wantDB:      true,
+			wantLikers:  []string{},
+		},
+		{
+			name:            "token issued for another recipient",
+			recipientID:     "user2",
+			paginationToken: testToken(likesOwner("user9"), 1000, "user1"),
+			wantErr:         pagination.ErrForeignCursor,
+		},
+		{
+			name:            "tampered token",
+			recipientID:     "user2",
+			paginationToken: "MTAwMHx1c2VyMQ==",
+			wantErr:         pagination.ErrInvalidCursor,
 		},
 	}
 
@@ -136,20 +458,45 @@ func TestExploreService_ListLikedYou(t *testing.T) {
 			mockRepo := db_mocks.NewRepository(t)
 			mockCache := redis_mocks.NewRepository(t)
 
-			mockCache.EXPECT().
-				GetLikers(ctx, tt.recipientID, tt.paginationToken).
-				Return(tt.mockCacheData, tt.mockCacheNext, tt.mockCacheErr).
-				Once()
+			var page pagination.Page
+			if tt.wantCache {
+				var err error
+				page, err = pagination.Resolve(decodeToken(t, tt.paginationToken, likesOwner(tt.recipientID)), tt.query.Direction, tt.query.Since, tt.query.Until)
+				require.NoError(t, err)
+				page.Size = tt.wantPageSize
+				mockCache.EXPECT().
+					GetLikers(mock.Anything, tt.recipientID, page).
+					Return(tt.mockCacheData, tt.mockCacheMore, tt.mockCacheErr).
+					Once()
+			}
+
+			if tt.wantCount {
+				mockCache.EXPECT().
+					CountLikes(mock.Anything, tt.recipientID).
+					Return(tt.mockCount, nil).
+					Once()
+			}
 
-			svc := New(mockRepo, mockCache)
-			got, nextToken, err := svc.ListLikedYou(ctx, tt.recipientID, tt.paginationToken)
+			if tt.wantDB {
+				mockRepo.EXPECT().
+					GetLikers(mock.Anything, tt.recipientID, page).
+					Return(tt.mockDBData, tt.mockDBMore, tt.mockDBErr).
+					Once()
+			}
 
-			if tt.wantErr {
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{WarmCacheBatchSize: 10, PaginationSecret: testSecret, PaginationMaxSize: 100})
+			got, nextToken, err := svc.ListLikedYou(ctx, tt.recipientID, tt.paginationToken, tt.query)
+
+			if tt.wantErr != nil {
 				assert.Error(t, err)
+				if errors.Is(tt.wantErr, pagination.ErrInvalidCursor) {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
 				return
 			}
 
 			assert.NoError(t, err)
+			assertRepopulate(t, svc, tt.wantRepopulate, tt.recipientID)
 
 			gotIDs := make([]string, len(got))
 			for i, l := range got {
@@ -157,7 +504,118 @@ func TestExploreService_ListLikedYou(t *testing.T) {
 			}
 			assert.Equal(t, tt.wantLikers, gotIDs)
 
-			assert.Equal(t, tt.wantNextToken, nextToken)
+			next := decodeToken(t, nextToken, likesOwner(tt.recipientID))
+			if tt.wantNextAfter == "" {
+				assert.Nil(t, next)
+			} else {
+				require.NotNil(t, next)
+				assert.Equal(t, tt.wantNextAfter, next.ID)
+
+				wantDirection := tt.wantDirection
+				if wantDirection == "" {
+					wantDirection = pagination.Ascending
+				}
+				assert.Equal(t, wantDirection, next.Direction)
+			}
+		})
+	}
+}
+
+func TestExploreService_PageSize(t *testing.T) {
+	svc := newTestService(t, nil, nil, &config.AppConfig{PaginationSize: 50, PaginationMaxSize: 200})
+
+	tests := []struct {
+		requested int
+		want      int
+	}{
+		{requested: 0, want: 50},
+		{requested: 10, want: 10},
+		{requested: 200, want: 200},
+		{requested: 1000, want: 200},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, svc.pageSize(tt.requested), "requested %d", tt.requested)
+	}
+}
+
+func TestExploreService_CountLikedYou(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		mockCacheCount int64
+		mockCacheErr   error
+		mockDBCount    uint64
+		mockDBErr      error
+		wantDB         bool
+		wantRepopulate bool
+		wantCount      uint64
+		wantErr        bool
+	}{
+		{
+			name:           "success",
+			mockCacheCount: 3,
+			wantCount:      3,
+		},
+		{
+			name:           "cache miss - falls back to db and repopulates",
+			mockCacheCount: 0,
+			mockDBCount:    2,
+			wantDB:         true,
+			wantRepopulate: true,
+			wantCount:      2,
+		},
+		{
+			name:           "no likes anywhere",
+			mockCacheCount: 0,
+			mockDBCount:    0,
+			wantDB:         true,
+			wantCount:      0,
+		},
+		{
+			name:         "cache error - falls back to db",
+			mockCacheErr: errors.New("redis error"),
+			mockDBCount:  2,
+			wantDB:       true,
+			wantCount:    2,
+		},
+		{
+			name:         "cache and db error",
+			mockCacheErr: errors.New("redis error"),
+			mockDBErr:    errors.New("db error"),
+			wantDB:       true,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			mockCache.EXPECT().
+				CountLikes(mock.Anything, "user2").
+				Return(tt.mockCacheCount, tt.mockCacheErr).
+				Once()
+
+			if tt.wantDB {
+				mockRepo.EXPECT().
+					CountLikes(mock.Anything, "user2").
+					Return(tt.mockDBCount, tt.mockDBErr).
+					Once()
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{WarmCacheBatchSize: 10})
+			count, err := svc.CountLikedYou(ctx, "user2")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assertRepopulate(t, svc, tt.wantRepopulate, "user2")
+			assert.Equal(t, tt.wantCount, count)
 		})
 	}
 }
@@ -166,50 +624,68 @@ func TestExploreService_ListNewLikedYou(t *testing.T) {
 	ctx := context.Background()
 
 	tests := []struct {
-		name             string
-		recipientID      string
-		paginationToken  string
-		mockCacheData    []redis.Z
-		mockCacheErr     error
-		mockCacheNext    string
-		mockHasLikedBack map[string]bool
-		mockHasLikedErr  error
-		wantLikers       []string
-		wantNextToken    string
-		wantErr          bool
+		name            string
+		paginationToken string
+		mockCacheData   []redis.Z
+		mockCacheMore   bool
+		mockCacheErr    error
+		wantCount       bool
+		mockCount       int64
+		mockCountErr    error
+		wantDB          bool
+		mockDBData      []repository.Liker
+		mockDBMore      bool
+		mockDBErr       error
+		wantRepopulate  bool
+		wantLikers      []string
+		wantNextAfter   string
+		wantErr         bool
 	}{
 		{
-			name:            "success - filter liked back",
-			recipientID:     "user2",
-			paginationToken: "",
+			name: "success - full page from cache",
 			mockCacheData: []redis.Z{
 				{Member: "user1", Score: 1000},
 				{Member: "user3", Score: 1100},
 			},
-			mockCacheNext: "token_from_cache",
-			mockHasLikedBack: map[string]bool{
-				"user1": false,
-				"user3": true,
+			mockCacheMore: true,
+			wantLikers:    []string{"user1", "user3"},
+			wantNextAfter: "user3",
+		},
+		{
+			name:       "every like reciprocated - empty from cache",
+			wantCount:  true,
+			mockCount:  4,
+			wantLikers: []string{},
+		},
+		{
+			name:      "cache miss - falls back to db and repopulates",
+			wantCount: true,
+			mockCount: 0,
+			wantDB:    true,
+			mockDBData: []repository.Liker{
+				{ActorId: "user1", UnixTimestamp: 1000},
 			},
-			wantLikers:    []string{"user1"},
-			wantNextToken: "token_from_cache",
-			wantErr:       false,
+			mockDBMore:     true,
+			wantRepopulate: true,
+			wantLikers:     []string{"user1"},
+			wantNextAfter:  "user1",
 		},
 		{
-			name:            "cache error",
-			recipientID:     "user2",
-			paginationToken: "",
+			name:            "cache error - falls back to db",
+			paginationToken: testToken(likesOwner("user2"), 900, "user0"),
 			mockCacheErr:    errors.New("redis error"),
-			wantErr:         true,
+			wantDB:          true,
+			mockDBData: []repository.Liker{
+				{ActorId: "user1", UnixTimestamp: 1000},
+			},
+			wantLikers: []string{"user1"},
 		},
 		{
-			name:            "HasRecipientLikedActor error",
-			recipientID:     "user2",
-			paginationToken: "",
-			mockCacheData:   []redis.Z{{Member: "user1", Score: 1}},
-			mockCacheNext:   "token_from_cache",
-			mockHasLikedErr: errors.New("db error"),
-			wantErr:         true,
+			name:         "cache and db error",
+			mockCacheErr: errors.New("redis error"),
+			wantDB:       true,
+			mockDBErr:    errors.New("db error"),
+			wantErr:      true,
 		},
 	}
 
@@ -218,21 +694,28 @@ func TestExploreService_ListNewLikedYou(t *testing.T) {
 			mockRepo := db_mocks.NewRepository(t)
 			mockCache := redis_mocks.NewRepository(t)
 
+			page := pagination.Page{After: decodeToken(t, tt.paginationToken, likesOwner("user2")), Direction: pagination.Ascending}
 			mockCache.EXPECT().
-				GetLikers(ctx, tt.recipientID, tt.paginationToken).
-				Return(tt.mockCacheData, tt.mockCacheNext, tt.mockCacheErr).
+				GetNewLikers(mock.Anything, "user2", page).
+				Return(tt.mockCacheData, tt.mockCacheMore, tt.mockCacheErr).
 				Once()
 
-			for _, z := range tt.mockCacheData {
-				member := z.Member.(string)
+			if tt.wantCount {
+				mockCache.EXPECT().
+					CountLikes(mock.Anything, "user2").
+					Return(tt.mockCount, tt.mockCountErr).
+					Once()
+			}
+
+			if tt.wantDB {
 				mockRepo.EXPECT().
-					HasRecipientLikedActor(ctx, tt.recipientID, member).
-					Return(tt.mockHasLikedBack[member], tt.mockHasLikedErr).
-					Maybe()
+					GetNewLikers(mock.Anything, "user2", page).
+					Return(tt.mockDBData, tt.mockDBMore, tt.mockDBErr).
+					Once()
 			}
 
-			svc := New(mockRepo, mockCache)
-			got, nextToken, err := svc.ListNewLikedYou(ctx, tt.recipientID, tt.paginationToken)
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{WarmCacheBatchSize: 10, PaginationSecret: testSecret})
+			got, nextToken, err := svc.ListNewLikedYou(ctx, "user2", tt.paginationToken, LikesQuery{})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -240,6 +723,7 @@ func TestExploreService_ListNewLikedYou(t *testing.T) {
 			}
 
 			assert.NoError(t, err)
+			assertRepopulate(t, svc, tt.wantRepopulate, "user2")
 
 			gotIDs := make([]string, len(got))
 			for i, l := range got {
@@ -247,7 +731,185 @@ func TestExploreService_ListNewLikedYou(t *testing.T) {
 			}
 			assert.Equal(t, tt.wantLikers, gotIDs)
 
-			assert.Equal(t, tt.wantNextToken, nextToken)
+			next := decodeToken(t, nextToken, likesOwner("user2"))
+			if tt.wantNextAfter == "" {
+				assert.Nil(t, next)
+			} else {
+				require.NotNil(t, next)
+				assert.Equal(t, tt.wantNextAfter, next.ID)
+			}
+		})
+	}
+}
+
+func TestExploreService_ListMatches(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		paginationToken string
+		wantAfter       string
+		mockMatches     []repository.Match
+		mockMore        bool
+		mockErr         error
+		wantMatches     []string
+		wantNextAfter   string
+		wantErr         error
+	}{
+		{
+			name: "success",
+			mockMatches: []repository.Match{
+				{UserId: "user1", UnixTimestamp: 1000},
+				{UserId: "user3", UnixTimestamp: 1100},
+			},
+			mockMore:      true,
+			wantMatches:   []string{"user1", "user3"},
+			wantNextAfter: "user3",
+		},
+		{
+			name:            "last page",
+			paginationToken: testToken(matchesOwner("user2"), 1100, "user3"),
+			wantAfter:       "user3",
+			mockMatches:     []repository.Match{{UserId: "user4", UnixTimestamp: 1200}},
+			wantMatches:     []string{"user4"},
+		},
+		{
+			name:            "likes token",
+			paginationToken: testToken(likesOwner("user2"), 1100, "user3"),
+			wantErr:         pagination.ErrForeignCursor,
+		},
+		{
+			name:            "newest first likes token",
+			paginationToken: testTokenIn(pagination.Descending, likesOwner("user2"), 1100, "user3"),
+			wantErr:         pagination.ErrForeignCursor,
+		},
+		{
+			name:    "db error",
+			mockErr: errors.New("db error"),
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			if !errors.Is(tt.wantErr, pagination.ErrInvalidCursor) {
+				mockRepo.EXPECT().
+					ListMatches(mock.Anything, "user2", mock.MatchedBy(func(c *pagination.Cursor) bool {
+						return (c == nil && tt.wantAfter == "") || (c != nil && c.ID == tt.wantAfter)
+					})).
+					Return(tt.mockMatches, tt.mockMore, tt.mockErr).
+					Once()
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{PaginationSecret: testSecret})
+			got, nextToken, err := svc.ListMatches(ctx, "user2", tt.paginationToken)
+
+			if tt.wantErr != nil {
+				if errors.Is(tt.wantErr, pagination.ErrInvalidCursor) {
+					assert.ErrorIs(t, err, tt.wantErr)
+				} else {
+					assert.Error(t, err)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+
+			gotIDs := make([]string, len(got))
+			for i, m := range got {
+				gotIDs[i] = m.UserId
+			}
+			assert.Equal(t, tt.wantMatches, gotIDs)
+			if tt.wantNextAfter == "" {
+				assert.Empty(t, nextToken)
+				return
+			}
+			assert.Equal(t, tt.wantNextAfter, decodeToken(t, nextToken, matchesOwner("user2")).ID)
+		})
+	}
+}
+
+func TestExploreService_GetDecisionHistory(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		recipientID     string
+		paginationToken string
+		wantAfter       string
+		mockEvents      []models.DecisionEvent
+		mockMore        bool
+		wantLiked       []bool
+		wantNextAfter   string
+		wantErr         error
+	}{
+		{
+			name: "first page",
+			mockEvents: []models.DecisionEvent{
+				{ID: 7, ActorUserID: "user1", RecipientUserID: "user2", Liked: true, Source: models.DecisionSourceAPI},
+				{ID: 4, ActorUserID: "user1", RecipientUserID: "user2", Liked: false, Source: models.DecisionSourceAPI},
+			},
+			mockMore:      true,
+			wantLiked:     []bool{true, false},
+			wantNextAfter: "4",
+		},
+		{
+			name:            "next page for a single recipient",
+			recipientID:     "user2",
+			paginationToken: testToken(historyOwner("user1", "user2"), 0, "4"),
+			wantAfter:       "4",
+			mockEvents: []models.DecisionEvent{
+				{ID: 2, ActorUserID: "user1", RecipientUserID: "user2", Liked: true},
+			},
+			wantLiked: []bool{true},
+		},
+		{
+			name:            "token of another listing",
+			recipientID:     "user3",
+			paginationToken: testToken(historyOwner("user1", "user2"), 0, "4"),
+			wantErr:         pagination.ErrForeignCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			if tt.wantErr == nil {
+				mockRepo.EXPECT().
+					GetDecisionHistory(mock.Anything, "user1", tt.recipientID, mock.MatchedBy(func(after *pagination.Cursor) bool {
+						return (after == nil && tt.wantAfter == "") || (after != nil && after.ID == tt.wantAfter)
+					})).
+					Return(tt.mockEvents, tt.mockMore, nil)
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{PaginationSecret: testSecret})
+			got, nextToken, err := svc.GetDecisionHistory(ctx, "user1", tt.recipientID, tt.paginationToken)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			gotLiked := make([]bool, len(got))
+			for i, e := range got {
+				gotLiked[i] = e.LikedRecipient
+			}
+			assert.Equal(t, tt.wantLiked, gotLiked)
+
+			next := decodeToken(t, nextToken, historyOwner("user1", tt.recipientID))
+			if tt.wantNextAfter == "" {
+				assert.Nil(t, next)
+			} else {
+				require.NotNil(t, next)
+				assert.Equal(t, tt.wantNextAfter, next.ID)
+				assert.Equal(t, pagination.Descending, next.Direction)
+			}
 		})
 	}
 }
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+
+	"github.com/endyapina/muzzapp/internal/metrics"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("github.com/endyapina/muzzapp/internal/service")
+
+// startSpan starts the span of a service method. the mysql and redis spans of the method
+// become its children, so a trace shows where a slow call spent its time.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "ExploreService."+name, trace.WithAttributes(attrs...))
+}
+
+// readFrom records which backend served a liked-you read, both as a metric and on span.
+func readFrom(span trace.Span, method, source string) {
+	metrics.LikesReads.WithLabelValues(method, source).Inc()
+	span.SetAttributes(attribute.String("likes.source", source))
+}
+
+// failSpan marks span as failed. spans of RPCs get their status from the gRPC span, so
+// this is only needed for the background work.
+func failSpan(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
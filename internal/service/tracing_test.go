@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/metrics"
+	"github.com/endyapina/muzzapp/internal/pagination"
+	"github.com/endyapina/muzzapp/internal/redis"
+	redis_mocks "github.com/endyapina/muzzapp/internal/redis/mocks"
+	db_mocks "github.com/endyapina/muzzapp/internal/repository/mocks"
+)
+
+func TestExploreService_ListLikedYouSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	mockRepo := db_mocks.NewRepository(t)
+	mockCache := redis_mocks.NewRepository(t)
+	mockCache.EXPECT().
+		GetLikers(mock.Anything, "user2", pagination.Page{Direction: pagination.Ascending}).
+		Return([]redis.Z{{Member: "user1", Score: 1000}}, false, nil)
+
+	svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{PaginationSecret: testSecret})
+	_, _, err := svc.ListLikedYou(context.Background(), "user2", "", LikesQuery{})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "ExploreService.ListLikedYou", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("recipient.id", "user2"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("likes.source", metrics.SourceRedis))
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/endyapina/muzzapp/internal/repository"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DeletionReport is what DeleteUserData erased, and whether the erasure could be verified.
+type DeletionReport struct {
+	repository.DeletionReport
+	// CacheCleared reports whether the user's own sorted sets were gone once the outbox
+	// was relayed
+	CacheCleared bool `json:"cache_cleared"`
+	// Verified reports whether no row holds the user id anymore and the cache was cleared
+	Verified bool `json:"verified"`
+}
+
+// DeleteUserData erases everything the service stores about a user, e.g. when their
+// account is deleted: the decisions they made and received, with their history and
+// expired passes, matches, blocks and idempotency keys, and their entries in the redis
+// cache.
+//
+// the outbox is relayed right away instead of on the next tick, so the report can tell
+// whether the cache was cleared. a deletion that isn't verified can be run again.
+func (s *ExploreService) DeleteUserData(ctx context.Context, userID string) (DeletionReport, error) {
+	ctx, span := startSpan(ctx, "DeleteUserData", attribute.String("user.id", userID))
+	defer span.End()
+
+	deleted, err := s.repo.DeleteUserData(ctx, userID)
+	report := DeletionReport{DeletionReport: deleted}
+	if err != nil {
+		s.logger.ErrorContext(ctx, "failed to delete user data", "user_id", userID, "decisions", deleted.Decisions, "error", err)
+		return report, err
+	}
+
+	if _, err := s.RelayOutbox(ctx); err != nil {
+		s.logger.WarnContext(ctx, "failed to relay user data removals, they stay queued", "user_id", userID, "error", err)
+	} else {
+		cached, err := s.cache.HasLikes(ctx, userID)
+		report.CacheCleared = err == nil && !cached
+	}
+	report.Verified = report.Remaining == 0 && report.CacheCleared
+
+	s.logger.InfoContext(ctx, "user data deleted", "user_id", userID,
+		"decisions", report.Decisions, "cached_likes", report.CachedLikes, "remaining", report.Remaining, "verified", report.Verified)
+	span.SetAttributes(attribute.Int64("deleted.decisions", report.Decisions), attribute.Bool("deleted.verified", report.Verified))
+	return report, nil
+}
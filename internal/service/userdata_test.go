@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	redis_mocks "github.com/endyapina/muzzapp/internal/redis/mocks"
+	"github.com/endyapina/muzzapp/internal/repository"
+	db_mocks "github.com/endyapina/muzzapp/internal/repository/mocks"
+)
+
+func TestExploreService_DeleteUserData(t *testing.T) {
+	ctx := context.Background()
+	deleted := repository.DeletionReport{Decisions: 4, Matches: 2, CachedLikes: 3}
+
+	tests := []struct {
+		name         string
+		mockReport   repository.DeletionReport
+		mockErr      error
+		relayErr     error
+		cached       bool
+		wantCleared  bool
+		wantVerified bool
+		wantErr      bool
+	}{
+		{name: "verified", mockReport: deleted, wantCleared: true, wantVerified: true},
+		{name: "rows left", mockReport: repository.DeletionReport{Decisions: 4, Remaining: 1}, wantCleared: true},
+		{name: "relay failed", mockReport: deleted, relayErr: errors.New("redis down")},
+		{name: "cache not cleared", mockReport: deleted, cached: true},
+		{name: "db error", mockErr: errors.New("db error"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			mockRepo.EXPECT().DeleteUserData(mock.Anything, "user1").Return(tt.mockReport, tt.mockErr).Once()
+			if tt.mockErr == nil {
+				mockRepo.EXPECT().ProcessOutbox(mock.Anything, 10, mock.Anything).Return(0, tt.relayErr).Once()
+			}
+			if tt.mockErr == nil && tt.relayErr == nil {
+				mockCache.EXPECT().HasLikes(mock.Anything, "user1").Return(tt.cached, nil).Once()
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{OutboxBatchSize: 10})
+			report, err := svc.DeleteUserData(ctx, "user1")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.mockReport, report.DeletionReport)
+			assert.Equal(t, tt.wantCleared, report.CacheCleared)
+			assert.Equal(t, tt.wantVerified, report.Verified)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/endyapina/muzzapp/internal/models"
+	redis_cache "github.com/endyapina/muzzapp/internal/redis"
+	"github.com/endyapina/muzzapp/internal/repository"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// WarmStats summarises a cache rebuild.
+type WarmStats struct {
+	Recipients int
+	Likes      int
+}
+
+// WarmCache rebuilds every "liked:<recipient>" and "new_liked:<recipient>" sorted set
+// from the decisions table.
+//
+// redis is only written to as a side effect of PutDecision, so after a flush, a failover
+// or a fresh deploy against an existing database the cache is empty and ListLikedYou /
+// CountLikedYou would silently return nothing. this streams the likes out of mysql in
+// batches ordered by recipient and swaps each recipient's sets in one go once all of its
+// rows have been read.
+//
+// only a single recipient's likes are held in memory at a time. for very hot profiles
+// with millions of likes you may want to stage the sets under temporary keys and RENAME
+// them into place instead.
+//
+// the relay keeps writing to the cache meanwhile, so each recipient's sets are swapped
+// through replaceLikes, which replays the mutations the snapshot may have missed.
+func (s *ExploreService) WarmCache(ctx context.Context) (WarmStats, error) {
+	ctx, span := startSpan(ctx, "WarmCache")
+	defer span.End()
+
+	stats, err := s.warmCache(ctx)
+	span.SetAttributes(attribute.Int("warm.recipients", stats.Recipients), attribute.Int("warm.likes", stats.Likes))
+	if err != nil {
+		failSpan(span, err)
+	}
+	return stats, err
+}
+
+func (s *ExploreService) warmCache(ctx context.Context) (WarmStats, error) {
+	var stats WarmStats
+	highWater, err := s.repo.OutboxHighWater(ctx)
+	if err != nil {
+		return stats, err
+	}
+
+	var current string
+	var likers, newLikers []redis_cache.Z
+	var last repository.ScannedLike
+
+	flush := func() error {
+		if current == "" {
+			return nil
+		}
+		if err := s.replaceLikes(ctx, s.repo.ReplayOutbox, current, highWater, likers, newLikers); err != nil {
+			return err
+		}
+		stats.Recipients++
+		stats.Likes += len(likers)
+		likers, newLikers = nil, nil
+		return nil
+	}
+
+	for {
+		batch, err := s.repo.ScanLikes(ctx, "", last.RecipientUserID, last.ActorUserID, s.config.WarmCacheBatchSize)
+		if err != nil {
+			return stats, err
+		}
+
+		for _, l := range batch {
+			if l.RecipientUserID != current {
+				if err := flush(); err != nil {
+					return stats, err
+				}
+				current = l.RecipientUserID
+			}
+			likers, newLikers = appendLike(likers, newLikers, l)
+		}
+
+		if len(batch) == 0 || len(batch) < s.config.WarmCacheBatchSize {
+			break
+		}
+		last = batch[len(batch)-1]
+	}
+
+	return stats, flush()
+}
+
+// WarmRecipient rebuilds the sorted sets of a single recipient from the decisions table
+// and returns the number of likes written. a recipient without likes ends up with no keys
+// at all.
+func (s *ExploreService) WarmRecipient(ctx context.Context, recipientID string) (int, error) {
+	return s.warmRecipient(ctx, s.repo.ReplayOutbox, recipientID)
+}
+
+func (s *ExploreService) warmRecipient(ctx context.Context, replay replayFunc, recipientID string) (int, error) {
+	highWater, err := s.repo.OutboxHighWater(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var likers, newLikers []redis_cache.Z
+	var last repository.ScannedLike
+
+	for {
+		batch, err := s.repo.ScanLikes(ctx, recipientID, last.RecipientUserID, last.ActorUserID, s.config.WarmCacheBatchSize)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, l := range batch {
+			likers, newLikers = appendLike(likers, newLikers, l)
+		}
+
+		if len(batch) == 0 || len(batch) < s.config.WarmCacheBatchSize {
+			break
+		}
+		last = batch[len(batch)-1]
+	}
+
+	if err := s.replaceLikes(ctx, replay, recipientID, highWater, likers, newLikers); err != nil {
+		return 0, err
+	}
+	return len(likers), nil
+}
+
+// replayFunc fences the swap of a recipient's sets against the relay, see
+// repository.ReplayOutbox and TryReplayOutbox.
+type replayFunc func(ctx context.Context, recipientID string, afterID uint64, apply func([]models.OutboxEvent) error) error
+
+// replaceLikes swaps the recipient's sorted sets for a snapshot of the decisions read after
+// the outbox event highWater, together with the recipient's events since, under the relay
+// lock. a like the relay applied between the snapshot and the swap is replayed instead of
+// being lost until the next rebuild.
+func (s *ExploreService) replaceLikes(ctx context.Context, replay replayFunc, recipientID string, highWater uint64, likers, newLikers []redis_cache.Z) error {
+	return replay(ctx, recipientID, highWater, func(events []models.OutboxEvent) error {
+		return s.cache.ReplaceLikes(ctx, recipientID, likers, newLikers, toMutations(events))
+	})
+}
+
+// repopulateQueueSize bounds the recipients waiting for a rebuild after a cache miss.
+// misses beyond it are dropped, a later read misses again and queues them then.
+const repopulateQueueSize = 256
+
+// repopulate queues a rebuild of the recipient's sets after a cache miss. the caller
+// already has its answer from the database, so it never waits: a recipient that is
+// already queued isn't queued twice, and a full queue drops the rebuild.
+func (s *ExploreService) repopulate(ctx context.Context, recipientID string) {
+	s.repopulateMu.Lock()
+	defer s.repopulateMu.Unlock()
+
+	if _, queued := s.repopulating[recipientID]; queued {
+		return
+	}
+	select {
+	case s.repopulates <- recipientID:
+		s.repopulating[recipientID] = struct{}{}
+	default:
+		s.logger.DebugContext(ctx, "repopulate queue full, dropping rebuild", "recipient_id", recipientID)
+	}
+}
+
+// RunRepopulator rebuilds the sets of the recipients queued by cache misses, one at a
+// time, until ctx is cancelled.
+//
+// a rebuild doesn't wait for the relay lock: when the relay or another rebuild holds it
+// the recipient is skipped, and the next miss queues it again. after a redis flush the
+// reads fall back to mysql meanwhile instead of queueing on the lock.
+func (s *ExploreService) RunRepopulator(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case recipientID := <-s.repopulates:
+			s.rebuild(ctx, recipientID)
+		}
+	}
+}
+
+// rebuild rebuilds the sets of a recipient queued by repopulate. the recipient stays
+// queued until the rebuild is done, so misses while it runs don't queue it again.
+func (s *ExploreService) rebuild(ctx context.Context, recipientID string) {
+	defer func() {
+		s.repopulateMu.Lock()
+		delete(s.repopulating, recipientID)
+		s.repopulateMu.Unlock()
+	}()
+
+	_, err := s.warmRecipient(ctx, s.repo.TryReplayOutbox, recipientID)
+	switch {
+	case errors.Is(err, repository.ErrRelayBusy):
+		s.logger.DebugContext(ctx, "relay lock busy, skipping cache repopulate", "recipient_id", recipientID)
+	case err != nil:
+		s.logger.WarnContext(ctx, "failed to repopulate redis cache", "recipient_id", recipientID, "error", err)
+	}
+}
+
+// appendLike adds a scanned like to the recipient's likers, and to its new likers when the
+// recipient hasn't liked the actor back.
+func appendLike(likers, newLikers []redis_cache.Z, l repository.ScannedLike) ([]redis_cache.Z, []redis_cache.Z) {
+	z := redis_cache.Z{Score: float64(l.UnixTimestamp), Member: l.ActorUserID}
+	likers = append(likers, z)
+	if !l.LikedBack {
+		newLikers = append(newLikers, z)
+	}
+	return likers, newLikers
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/endyapina/muzzapp/internal/config"
+	"github.com/endyapina/muzzapp/internal/models"
+	"github.com/endyapina/muzzapp/internal/redis"
+	redis_mocks "github.com/endyapina/muzzapp/internal/redis/mocks"
+	"github.com/endyapina/muzzapp/internal/repository"
+	db_mocks "github.com/endyapina/muzzapp/internal/repository/mocks"
+)
+
+func TestExploreService_WarmCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success - recipient spanning batches", func(t *testing.T) {
+		mockRepo := db_mocks.NewRepository(t)
+		mockCache := redis_mocks.NewRepository(t)
+
+		// a like of alice relayed after the high-water mark is replayed with her snapshot
+		mockRepo.EXPECT().OutboxHighWater(mock.Anything).Return(uint64(5), nil).Once()
+		expectReplay(mockRepo, "alice", 5, []models.OutboxEvent{
+			{ID: 6, Op: models.OutboxAddLike, RecipientUserID: "alice", ActorUserID: "user4", UnixTimestamp: 400},
+		})
+		expectReplay(mockRepo, "bob", 5, nil)
+		mockRepo.EXPECT().
+			ScanLikes(mock.Anything, "", "", "", 2).
+			Return([]repository.ScannedLike{
+				{ActorUserID: "user1", RecipientUserID: "alice", UnixTimestamp: 100},
+				{ActorUserID: "user2", RecipientUserID: "bob", UnixTimestamp: 200, LikedBack: true},
+			}, nil).
+			Once()
+		mockRepo.EXPECT().
+			ScanLikes(mock.Anything, "", "bob", "user2", 2).
+			Return([]repository.ScannedLike{
+				{ActorUserID: "user3", RecipientUserID: "bob", UnixTimestamp: 300},
+			}, nil).
+			Once()
+
+		mockCache.EXPECT().
+			ReplaceLikes(mock.Anything, "alice", []redis.Z{{Score: 100, Member: "user1"}}, []redis.Z{{Score: 100, Member: "user1"}}, []redis.Mutation{
+				{Op: redis.MutationAddLike, RecipientID: "alice", ActorID: "user4", Timestamp: 400},
+			}).
+			Return(nil).
+			Once()
+		mockCache.EXPECT().
+			ReplaceLikes(mock.Anything, "bob", []redis.Z{{Score: 200, Member: "user2"}, {Score: 300, Member: "user3"}}, []redis.Z{{Score: 300, Member: "user3"}}, []redis.Mutation{}).
+			Return(nil).
+			Once()
+
+		svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{WarmCacheBatchSize: 2})
+		stats, err := svc.WarmCache(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, WarmStats{Recipients: 2, Likes: 3}, stats)
+	})
+
+	t.Run("empty table", func(t *testing.T) {
+		mockRepo := db_mocks.NewRepository(t)
+		mockCache := redis_mocks.NewRepository(t)
+
+		mockRepo.EXPECT().OutboxHighWater(mock.Anything).Return(uint64(0), nil).Once()
+		mockRepo.EXPECT().
+			ScanLikes(mock.Anything, "", "", "", 2).
+			Return(nil, nil).
+			Once()
+
+		svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{WarmCacheBatchSize: 2})
+		stats, err := svc.WarmCache(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, WarmStats{}, stats)
+	})
+
+	t.Run("zero batch size stops on the empty batch", func(t *testing.T) {
+		mockRepo := db_mocks.NewRepository(t)
+		mockCache := redis_mocks.NewRepository(t)
+
+		mockRepo.EXPECT().OutboxHighWater(mock.Anything).Return(uint64(0), nil).Once()
+		mockRepo.EXPECT().
+			ScanLikes(mock.Anything, "", "", "", 0).
+			Return(nil, nil).
+			Once()
+
+		svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{})
+		stats, err := svc.WarmCache(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, WarmStats{}, stats)
+	})
+
+	t.Run("cache error", func(t *testing.T) {
+		mockRepo := db_mocks.NewRepository(t)
+		mockCache := redis_mocks.NewRepository(t)
+
+		mockRepo.EXPECT().OutboxHighWater(mock.Anything).Return(uint64(0), nil).Once()
+		expectReplay(mockRepo, "alice", 0, nil)
+		mockRepo.EXPECT().
+			ScanLikes(mock.Anything, "", "", "", 2).
+			Return([]repository.ScannedLike{
+				{ActorUserID: "user1", RecipientUserID: "alice", UnixTimestamp: 100},
+			}, nil).
+			Once()
+		mockCache.EXPECT().
+			ReplaceLikes(mock.Anything, "alice", []redis.Z{{Score: 100, Member: "user1"}}, []redis.Z{{Score: 100, Member: "user1"}}, []redis.Mutation{}).
+			Return(errors.New("redis error")).
+			Once()
+
+		svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{WarmCacheBatchSize: 2})
+		_, err := svc.WarmCache(ctx)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestExploreService_WarmRecipient(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		mockScan      []repository.ScannedLike
+		mockScanErr   error
+		wantLikers    []redis.Z
+		wantNewLikers []redis.Z
+		wantCount     int
+		wantErr       bool
+	}{
+		{
+			name: "success",
+			mockScan: []repository.ScannedLike{
+				{ActorUserID: "user1", RecipientUserID: "alice", UnixTimestamp: 100},
+				{ActorUserID: "user2", RecipientUserID: "alice", UnixTimestamp: 200, LikedBack: true},
+			},
+			wantLikers:    []redis.Z{{Score: 100, Member: "user1"}, {Score: 200, Member: "user2"}},
+			wantNewLikers: []redis.Z{{Score: 100, Member: "user1"}},
+			wantCount:     2,
+		},
+		{
+			name:      "no likes clears the key",
+			wantCount: 0,
+		},
+		{
+			name:        "db error",
+			mockScanErr: errors.New("db error"),
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			mockRepo.EXPECT().OutboxHighWater(mock.Anything).Return(uint64(0), nil).Once()
+			mockRepo.EXPECT().
+				ScanLikes(mock.Anything, "alice", "", "", 10).
+				Return(tt.mockScan, tt.mockScanErr).
+				Once()
+
+			if tt.mockScanErr == nil {
+				expectReplay(mockRepo, "alice", 0, nil)
+				mockCache.EXPECT().
+					ReplaceLikes(mock.Anything, "alice", tt.wantLikers, tt.wantNewLikers, []redis.Mutation{}).
+					Return(nil).
+					Once()
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{WarmCacheBatchSize: 10})
+			count, err := svc.WarmRecipient(ctx, "alice")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func TestExploreService_Repopulate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		replayErr error
+	}{
+		{name: "rebuilt"},
+		{name: "relay busy - skipped", replayErr: repository.ErrRelayBusy},
+		{name: "redis error", replayErr: errors.New("redis error")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db_mocks.NewRepository(t)
+			mockCache := redis_mocks.NewRepository(t)
+
+			mockRepo.EXPECT().OutboxHighWater(mock.Anything).Return(uint64(3), nil).Once()
+			mockRepo.EXPECT().
+				ScanLikes(mock.Anything, "alice", "", "", 10).
+				Return([]repository.ScannedLike{{RecipientUserID: "alice", ActorUserID: "user1", UnixTimestamp: 100}}, nil).
+				Once()
+			// rebuilds on a miss never wait for the relay lock
+			mockRepo.EXPECT().
+				TryReplayOutbox(mock.Anything, "alice", uint64(3), mock.Anything).
+				RunAndReturn(func(_ context.Context, _ string, _ uint64, apply func([]models.OutboxEvent) error) error {
+					if tt.replayErr != nil {
+						return tt.replayErr
+					}
+					return apply(nil)
+				}).
+				Once()
+			if tt.replayErr == nil {
+				z := []redis.Z{{Score: 100, Member: "user1"}}
+				mockCache.EXPECT().ReplaceLikes(mock.Anything, "alice", z, z, []redis.Mutation{}).Return(nil).Once()
+			}
+
+			svc := newTestService(t, mockRepo, mockCache, &config.AppConfig{WarmCacheBatchSize: 10})
+
+			// misses of a queued recipient don't queue it twice
+			svc.repopulate(ctx, "alice")
+			svc.repopulate(ctx, "alice")
+			require.Len(t, svc.repopulates, 1)
+
+			svc.rebuild(ctx, <-svc.repopulates)
+
+			// whatever the rebuild did, the next miss queues the recipient again
+			svc.repopulate(ctx, "alice")
+			assert.Len(t, svc.repopulates, 1)
+		})
+	}
+}
+
+func TestExploreService_Repopulate_QueueFull(t *testing.T) {
+	svc := newTestService(t, db_mocks.NewRepository(t), redis_mocks.NewRepository(t), &config.AppConfig{})
+
+	for i := range repopulateQueueSize + 1 {
+		svc.repopulate(context.Background(), fmt.Sprintf("user%d", i))
+	}
+
+	// the rebuild that didn't fit is dropped rather than waited for, and not left queued
+	assert.Len(t, svc.repopulates, repopulateQueueSize)
+	assert.Len(t, svc.repopulating, repopulateQueueSize)
+}
+
+// expectReplay expects a rebuild of the recipient's sets fenced at highWater, replaying
+// events.
+func expectReplay(mockRepo *db_mocks.Repository, recipientID string, highWater uint64, events []models.OutboxEvent) {
+	mockRepo.EXPECT().
+		ReplayOutbox(mock.Anything, recipientID, highWater, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ uint64, apply func([]models.OutboxEvent) error) error {
+			return apply(events)
+		}).
+		Once()
+}
+
+// assertRepopulate asserts whether a read queued a rebuild of the recipient's sets.
+func assertRepopulate(t *testing.T, svc *ExploreService, want bool, recipientID string) {
+	t.Helper()
+	if !want {
+		assert.Empty(t, svc.repopulates)
+		return
+	}
+	require.Len(t, svc.repopulates, 1)
+	assert.Equal(t, recipientID, <-svc.repopulates)
+}
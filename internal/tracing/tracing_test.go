@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/endyapina/muzzapp/internal/config"
+)
+
+func TestInit(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+	}{
+		{name: "disabled", exporter: ExporterNone},
+		{name: "stdout", exporter: ExporterStdout},
+		{name: "unknown exporter", exporter: "zipkin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			shutdown, err := Init(ctx, &config.AppConfig{
+				ServiceName:        "muzzapp-test",
+				TracingExporter:    tt.exporter,
+				TracingSampleRatio: 1,
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NoError(t, shutdown(ctx))
+		})
+	}
+}
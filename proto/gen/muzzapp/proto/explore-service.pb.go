@@ -21,10 +21,165 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Order of a listing by timestamp
+type Order int32
+
+const (
+	Order_ORDER_UNSPECIFIED  Order = 0 // Oldest first, or the order of the pagination token
+	Order_ORDER_OLDEST_FIRST Order = 1
+	Order_ORDER_NEWEST_FIRST Order = 2
+)
+
+// Enum value maps for Order.
+var (
+	Order_name = map[int32]string{
+		0: "ORDER_UNSPECIFIED",
+		1: "ORDER_OLDEST_FIRST",
+		2: "ORDER_NEWEST_FIRST",
+	}
+	Order_value = map[string]int32{
+		"ORDER_UNSPECIFIED":  0,
+		"ORDER_OLDEST_FIRST": 1,
+		"ORDER_NEWEST_FIRST": 2,
+	}
+)
+
+func (x Order) Enum() *Order {
+	p := new(Order)
+	*p = x
+	return p
+}
+
+func (x Order) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Order) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_explore_service_proto_enumTypes[0].Descriptor()
+}
+
+func (Order) Type() protoreflect.EnumType {
+	return &file_proto_explore_service_proto_enumTypes[0]
+}
+
+func (x Order) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Order.Descriptor instead.
+func (Order) EnumDescriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{0}
+}
+
+type WatchLikedYouResponse_Type int32
+
+const (
+	WatchLikedYouResponse_TYPE_UNSPECIFIED WatchLikedYouResponse_Type = 0
+	WatchLikedYouResponse_TYPE_LIKED       WatchLikedYouResponse_Type = 1 // The actor liked the recipient
+	WatchLikedYouResponse_TYPE_UNLIKED     WatchLikedYouResponse_Type = 2 // The actor withdrew their like
+	WatchLikedYouResponse_TYPE_MATCHED     WatchLikedYouResponse_Type = 3 // The actor and the recipient like each other
+)
+
+// Enum value maps for WatchLikedYouResponse_Type.
+var (
+	WatchLikedYouResponse_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_LIKED",
+		2: "TYPE_UNLIKED",
+		3: "TYPE_MATCHED",
+	}
+	WatchLikedYouResponse_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_LIKED":       1,
+		"TYPE_UNLIKED":     2,
+		"TYPE_MATCHED":     3,
+	}
+)
+
+func (x WatchLikedYouResponse_Type) Enum() *WatchLikedYouResponse_Type {
+	p := new(WatchLikedYouResponse_Type)
+	*p = x
+	return p
+}
+
+func (x WatchLikedYouResponse_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WatchLikedYouResponse_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_explore_service_proto_enumTypes[1].Descriptor()
+}
+
+func (WatchLikedYouResponse_Type) Type() protoreflect.EnumType {
+	return &file_proto_explore_service_proto_enumTypes[1]
+}
+
+func (x WatchLikedYouResponse_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WatchLikedYouResponse_Type.Descriptor instead.
+func (WatchLikedYouResponse_Type) EnumDescriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type ExportUserDataRequest_Format int32
+
+const (
+	ExportUserDataRequest_FORMAT_UNSPECIFIED ExportUserDataRequest_Format = 0 // JSON Lines
+	ExportUserDataRequest_FORMAT_JSON_LINES  ExportUserDataRequest_Format = 1
+	ExportUserDataRequest_FORMAT_CSV         ExportUserDataRequest_Format = 2 // With a header row
+)
+
+// Enum value maps for ExportUserDataRequest_Format.
+var (
+	ExportUserDataRequest_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "FORMAT_JSON_LINES",
+		2: "FORMAT_CSV",
+	}
+	ExportUserDataRequest_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"FORMAT_JSON_LINES":  1,
+		"FORMAT_CSV":         2,
+	}
+)
+
+func (x ExportUserDataRequest_Format) Enum() *ExportUserDataRequest_Format {
+	p := new(ExportUserDataRequest_Format)
+	*p = x
+	return p
+}
+
+func (x ExportUserDataRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportUserDataRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_explore_service_proto_enumTypes[2].Descriptor()
+}
+
+func (ExportUserDataRequest_Format) Type() protoreflect.EnumType {
+	return &file_proto_explore_service_proto_enumTypes[2]
+}
+
+func (x ExportUserDataRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportUserDataRequest_Format.Descriptor instead.
+func (ExportUserDataRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{29, 0}
+}
+
 type ListLikedYouRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
 	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	Order           Order                  `protobuf:"varint,3,opt,name=order,proto3,enum=explore.Order" json:"order,omitempty"`          // Must match the order of the pagination token, if both are set
+	Since           *uint64                `protobuf:"varint,4,opt,name=since,proto3,oneof" json:"since,omitempty"`                       // Only list likes at or after this unix timestamp
+	Until           *uint64                `protobuf:"varint,5,opt,name=until,proto3,oneof" json:"until,omitempty"`                       // Only list likes at or before this unix timestamp
+	PageSize        *uint32                `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"` // Defaults to the server's page size, larger sizes are capped at its maximum
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -73,6 +228,34 @@ func (x *ListLikedYouRequest) GetPaginationToken() string {
 	return ""
 }
 
+func (x *ListLikedYouRequest) GetOrder() Order {
+	if x != nil {
+		return x.Order
+	}
+	return Order_ORDER_UNSPECIFIED
+}
+
+func (x *ListLikedYouRequest) GetSince() uint64 {
+	if x != nil && x.Since != nil {
+		return *x.Since
+	}
+	return 0
+}
+
+func (x *ListLikedYouRequest) GetUntil() uint64 {
+	if x != nil && x.Until != nil {
+		return *x.Until
+	}
+	return 0
+}
+
+func (x *ListLikedYouRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
 type ListLikedYouResponse struct {
 	state               protoimpl.MessageState        `protogen:"open.v1"`
 	Likers              []*ListLikedYouResponse_Liker `protobuf:"bytes,1,rep,name=likers,proto3" json:"likers,omitempty"`
@@ -125,6 +308,112 @@ func (x *ListLikedYouResponse) GetNextPaginationToken() string {
 	return ""
 }
 
+type WatchLikedYouRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WatchLikedYouRequest) Reset() {
+	*x = WatchLikedYouRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLikedYouRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLikedYouRequest) ProtoMessage() {}
+
+func (x *WatchLikedYouRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLikedYouRequest.ProtoReflect.Descriptor instead.
+func (*WatchLikedYouRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WatchLikedYouRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+// Events are delivered at most once while the stream is open; list the likes after
+// (re)connecting to catch up on anything missed
+type WatchLikedYouResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Type          WatchLikedYouResponse_Type `protobuf:"varint,1,opt,name=type,proto3,enum=explore.WatchLikedYouResponse_Type" json:"type,omitempty"`
+	ActorId       string                     `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	UnixTimestamp uint64                     `protobuf:"varint,3,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchLikedYouResponse) Reset() {
+	*x = WatchLikedYouResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchLikedYouResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchLikedYouResponse) ProtoMessage() {}
+
+func (x *WatchLikedYouResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchLikedYouResponse.ProtoReflect.Descriptor instead.
+func (*WatchLikedYouResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WatchLikedYouResponse) GetType() WatchLikedYouResponse_Type {
+	if x != nil {
+		return x.Type
+	}
+	return WatchLikedYouResponse_TYPE_UNSPECIFIED
+}
+
+func (x *WatchLikedYouResponse) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *WatchLikedYouResponse) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
 type CountLikedYouRequest struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
@@ -134,7 +423,7 @@ type CountLikedYouRequest struct {
 
 func (x *CountLikedYouRequest) Reset() {
 	*x = CountLikedYouRequest{}
-	mi := &file_proto_explore_service_proto_msgTypes[2]
+	mi := &file_proto_explore_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -146,7 +435,7 @@ func (x *CountLikedYouRequest) String() string {
 func (*CountLikedYouRequest) ProtoMessage() {}
 
 func (x *CountLikedYouRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_service_proto_msgTypes[2]
+	mi := &file_proto_explore_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,7 +448,7 @@ func (x *CountLikedYouRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountLikedYouRequest.ProtoReflect.Descriptor instead.
 func (*CountLikedYouRequest) Descriptor() ([]byte, []int) {
-	return file_proto_explore_service_proto_rawDescGZIP(), []int{2}
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CountLikedYouRequest) GetRecipientUserId() string {
@@ -178,7 +467,7 @@ type CountLikedYouResponse struct {
 
 func (x *CountLikedYouResponse) Reset() {
 	*x = CountLikedYouResponse{}
-	mi := &file_proto_explore_service_proto_msgTypes[3]
+	mi := &file_proto_explore_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -190,7 +479,7 @@ func (x *CountLikedYouResponse) String() string {
 func (*CountLikedYouResponse) ProtoMessage() {}
 
 func (x *CountLikedYouResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_service_proto_msgTypes[3]
+	mi := &file_proto_explore_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -203,7 +492,7 @@ func (x *CountLikedYouResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountLikedYouResponse.ProtoReflect.Descriptor instead.
 func (*CountLikedYouResponse) Descriptor() ([]byte, []int) {
-	return file_proto_explore_service_proto_rawDescGZIP(), []int{3}
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CountLikedYouResponse) GetCount() uint64 {
@@ -218,13 +507,14 @@ type PutDecisionRequest struct {
 	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
 	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
 	LikedRecipient  bool                   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	IdempotencyKey  *string                `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"` // Retries with the same key return the first outcome instead of recording the decision again
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *PutDecisionRequest) Reset() {
 	*x = PutDecisionRequest{}
-	mi := &file_proto_explore_service_proto_msgTypes[4]
+	mi := &file_proto_explore_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -236,7 +526,7 @@ func (x *PutDecisionRequest) String() string {
 func (*PutDecisionRequest) ProtoMessage() {}
 
 func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_service_proto_msgTypes[4]
+	mi := &file_proto_explore_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -249,7 +539,7 @@ func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionRequest.ProtoReflect.Descriptor instead.
 func (*PutDecisionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_explore_service_proto_rawDescGZIP(), []int{4}
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PutDecisionRequest) GetActorUserId() string {
@@ -273,6 +563,13 @@ func (x *PutDecisionRequest) GetLikedRecipient() bool {
 	return false
 }
 
+func (x *PutDecisionRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
 type PutDecisionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MutualLikes   bool                   `protobuf:"varint,1,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"` // True if both users like each other
@@ -282,7 +579,7 @@ type PutDecisionResponse struct {
 
 func (x *PutDecisionResponse) Reset() {
 	*x = PutDecisionResponse{}
-	mi := &file_proto_explore_service_proto_msgTypes[5]
+	mi := &file_proto_explore_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -294,7 +591,7 @@ func (x *PutDecisionResponse) String() string {
 func (*PutDecisionResponse) ProtoMessage() {}
 
 func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_service_proto_msgTypes[5]
+	mi := &file_proto_explore_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -307,7 +604,7 @@ func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionResponse.ProtoReflect.Descriptor instead.
 func (*PutDecisionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_explore_service_proto_rawDescGZIP(), []int{5}
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PutDecisionResponse) GetMutualLikes() bool {
@@ -317,29 +614,29 @@ func (x *PutDecisionResponse) GetMutualLikes() bool {
 	return false
 }
 
-type ListLikedYouResponse_Liker struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
-	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+type PutDecisionsRequest struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	ActorUserId   string                          `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	Decisions     []*PutDecisionsRequest_Decision `protobuf:"bytes,2,rep,name=decisions,proto3" json:"decisions,omitempty"` // Recorded in order, in a single transaction
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListLikedYouResponse_Liker) Reset() {
-	*x = ListLikedYouResponse_Liker{}
-	mi := &file_proto_explore_service_proto_msgTypes[6]
+func (x *PutDecisionsRequest) Reset() {
+	*x = PutDecisionsRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListLikedYouResponse_Liker) String() string {
+func (x *PutDecisionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+func (*PutDecisionsRequest) ProtoMessage() {}
 
-func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_explore_service_proto_msgTypes[6]
+func (x *PutDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,56 +647,1731 @@ func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
-func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
-	return file_proto_explore_service_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use PutDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*PutDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListLikedYouResponse_Liker) GetActorId() string {
+func (x *PutDecisionsRequest) GetActorUserId() string {
 	if x != nil {
-		return x.ActorId
+		return x.ActorUserId
 	}
 	return ""
 }
 
-func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+func (x *PutDecisionsRequest) GetDecisions() []*PutDecisionsRequest_Decision {
 	if x != nil {
-		return x.UnixTimestamp
+		return x.Decisions
 	}
-	return 0
+	return nil
 }
 
-var File_proto_explore_service_proto protoreflect.FileDescriptor
+type PutDecisionsResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Results       []*PutDecisionsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"` // One per decision, in request order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_explore_service_proto_rawDesc = "" +
-	"\n" +
-	"\x1bproto/explore-service.proto\x12\aexplore\"\x86\x01\n" +
-	"\x13ListLikedYouRequest\x12*\n" +
-	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12.\n" +
-	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01B\x13\n" +
-	"\x11_pagination_token\"\xf1\x01\n" +
-	"\x14ListLikedYouResponse\x12;\n" +
-	"\x06likers\x18\x01 \x03(\v2#.explore.ListLikedYouResponse.LikerR\x06likers\x127\n" +
-	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aI\n" +
-	"\x05Liker\x12\x19\n" +
-	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12%\n" +
-	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
-	"\x16_next_pagination_token\"B\n" +
-	"\x14CountLikedYouRequest\x12*\n" +
-	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\"-\n" +
-	"\x15CountLikedYouResponse\x12\x14\n" +
-	"\x05count\x18\x01 \x01(\x04R\x05count\"\x8d\x01\n" +
-	"\x12PutDecisionRequest\x12\"\n" +
-	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
-	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
-	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\"8\n" +
-	"\x13PutDecisionResponse\x12!\n" +
-	"\fmutual_likes\x18\x01 \x01(\bR\vmutualLikes2\xc7\x02\n" +
-	"\x0eExploreService\x12K\n" +
-	"\fListLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
-	"\x0fListNewLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
-	"\rCountLikedYou\x12\x1d.explore.CountLikedYouRequest\x1a\x1e.explore.CountLikedYouResponse\x12H\n" +
-	"\vPutDecision\x12\x1b.explore.PutDecisionRequest\x1a\x1c.explore.PutDecisionResponseB\x0fZ\rmuzzapp/protob\x06proto3"
+func (x *PutDecisionsResponse) Reset() {
+	*x = PutDecisionsResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionsResponse) ProtoMessage() {}
+
+func (x *PutDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*PutDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PutDecisionsResponse) GetResults() []*PutDecisionsResponse_Result {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type UndoDecisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId   string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndoDecisionRequest) Reset() {
+	*x = UndoDecisionRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoDecisionRequest) ProtoMessage() {}
+
+func (x *UndoDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoDecisionRequest.ProtoReflect.Descriptor instead.
+func (*UndoDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UndoDecisionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+type UndoDecisionResponse struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId        string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`                             // Recipient of the undone decision
+	LikedRecipient         bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`                                 // What the undone decision was
+	RestoredLikedRecipient *bool                  `protobuf:"varint,3,opt,name=restored_liked_recipient,json=restoredLikedRecipient,proto3,oneof" json:"restored_liked_recipient,omitempty"` // The decision in force again, unset if the actor hadn't decided about the recipient before
+	MutualLikes            bool                   `protobuf:"varint,4,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"`                                          // True if both users like each other after the undo
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *UndoDecisionResponse) Reset() {
+	*x = UndoDecisionResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndoDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndoDecisionResponse) ProtoMessage() {}
+
+func (x *UndoDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndoDecisionResponse.ProtoReflect.Descriptor instead.
+func (*UndoDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UndoDecisionResponse) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *UndoDecisionResponse) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *UndoDecisionResponse) GetRestoredLikedRecipient() bool {
+	if x != nil && x.RestoredLikedRecipient != nil {
+		return *x.RestoredLikedRecipient
+	}
+	return false
+}
+
+func (x *UndoDecisionResponse) GetMutualLikes() bool {
+	if x != nil {
+		return x.MutualLikes
+	}
+	return false
+}
+
+type GetDecisionRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDecisionRequest) Reset() {
+	*x = GetDecisionRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionRequest) ProtoMessage() {}
+
+func (x *GetDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionRequest.ProtoReflect.Descriptor instead.
+func (*GetDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetDecisionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *GetDecisionRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+type GetDecisionResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Decided              bool                   `protobuf:"varint,1,opt,name=decided,proto3" json:"decided,omitempty"` // False if the actor never decided about the recipient, or their pass expired
+	LikedRecipient       bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	UnixTimestamp        uint64                 `protobuf:"varint,3,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`                              // When the decision was made
+	ExpiresUnixTimestamp *uint64                `protobuf:"varint,4,opt,name=expires_unix_timestamp,json=expiresUnixTimestamp,proto3,oneof" json:"expires_unix_timestamp,omitempty"` // When a pass expires, unset for likes or if passes never expire
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetDecisionResponse) Reset() {
+	*x = GetDecisionResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionResponse) ProtoMessage() {}
+
+func (x *GetDecisionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionResponse.ProtoReflect.Descriptor instead.
+func (*GetDecisionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetDecisionResponse) GetDecided() bool {
+	if x != nil {
+		return x.Decided
+	}
+	return false
+}
+
+func (x *GetDecisionResponse) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *GetDecisionResponse) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *GetDecisionResponse) GetExpiresUnixTimestamp() uint64 {
+	if x != nil && x.ExpiresUnixTimestamp != nil {
+		return *x.ExpiresUnixTimestamp
+	}
+	return 0
+}
+
+type HasDecidedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Decided       bool                   `protobuf:"varint,1,opt,name=decided,proto3" json:"decided,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HasDecidedResponse) Reset() {
+	*x = HasDecidedResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HasDecidedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HasDecidedResponse) ProtoMessage() {}
+
+func (x *HasDecidedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HasDecidedResponse.ProtoReflect.Descriptor instead.
+func (*HasDecidedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HasDecidedResponse) GetDecided() bool {
+	if x != nil {
+		return x.Decided
+	}
+	return false
+}
+
+type ListMatchesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListMatchesRequest) Reset() {
+	*x = ListMatchesRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesRequest) ProtoMessage() {}
+
+func (x *ListMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesRequest.ProtoReflect.Descriptor instead.
+func (*ListMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMatchesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMatchesRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+type ListMatchesResponse struct {
+	state               protoimpl.MessageState       `protogen:"open.v1"`
+	Matches             []*ListMatchesResponse_Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	NextPaginationToken *string                      `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListMatchesResponse) Reset() {
+	*x = ListMatchesResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse) ProtoMessage() {}
+
+func (x *ListMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMatchesResponse) GetMatches() []*ListMatchesResponse_Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ListMatchesResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type CountMatchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountMatchesRequest) Reset() {
+	*x = CountMatchesRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountMatchesRequest) ProtoMessage() {}
+
+func (x *CountMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountMatchesRequest.ProtoReflect.Descriptor instead.
+func (*CountMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CountMatchesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CountMatchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountMatchesResponse) Reset() {
+	*x = CountMatchesResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountMatchesResponse) ProtoMessage() {}
+
+func (x *CountMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountMatchesResponse.ProtoReflect.Descriptor instead.
+func (*CountMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CountMatchesResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetDecisionHistoryRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId *string                `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3,oneof" json:"recipient_user_id,omitempty"` // Only list the decisions about this recipient
+	PaginationToken *string                `protobuf:"bytes,3,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDecisionHistoryRequest) Reset() {
+	*x = GetDecisionHistoryRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionHistoryRequest) ProtoMessage() {}
+
+func (x *GetDecisionHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetDecisionHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetDecisionHistoryRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *GetDecisionHistoryRequest) GetRecipientUserId() string {
+	if x != nil && x.RecipientUserId != nil {
+		return *x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *GetDecisionHistoryRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+type GetDecisionHistoryResponse struct {
+	state               protoimpl.MessageState              `protogen:"open.v1"`
+	Events              []*GetDecisionHistoryResponse_Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPaginationToken *string                             `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetDecisionHistoryResponse) Reset() {
+	*x = GetDecisionHistoryResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionHistoryResponse) ProtoMessage() {}
+
+func (x *GetDecisionHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetDecisionHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetDecisionHistoryResponse) GetEvents() []*GetDecisionHistoryResponse_Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetDecisionHistoryResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type BlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockedUserId string                 `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	Reason        *string                `protobuf:"bytes,3,opt,name=reason,proto3,oneof" json:"reason,omitempty"` // Why the user was reported, kept for moderation
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BlockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
+}
+
+type BlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockUserResponse) Reset() {
+	*x = BlockUserResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserResponse) ProtoMessage() {}
+
+func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{22}
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BlockedUserId string                 `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UnblockUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnblockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+type UnblockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnblockUserResponse) Reset() {
+	*x = UnblockUserResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnblockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserResponse) ProtoMessage() {}
+
+func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{24}
+}
+
+type ListBlockedRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string                `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListBlockedRequest) Reset() {
+	*x = ListBlockedRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedRequest) ProtoMessage() {}
+
+func (x *ListBlockedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedRequest.ProtoReflect.Descriptor instead.
+func (*ListBlockedRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListBlockedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListBlockedRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+type ListBlockedResponse struct {
+	state               protoimpl.MessageState         `protogen:"open.v1"`
+	Blocked             []*ListBlockedResponse_Blocked `protobuf:"bytes,1,rep,name=blocked,proto3" json:"blocked,omitempty"`
+	NextPaginationToken *string                        `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse) Reset() {
+	*x = ListBlockedResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse) ProtoMessage() {}
+
+func (x *ListBlockedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListBlockedResponse) GetBlocked() []*ListBlockedResponse_Blocked {
+	if x != nil {
+		return x.Blocked
+	}
+	return nil
+}
+
+func (x *ListBlockedResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type DeleteUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataRequest) Reset() {
+	*x = DeleteUserDataRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataRequest) ProtoMessage() {}
+
+func (x *DeleteUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Rows deleted per table, and whether the deletion was verified. A deletion that isn't
+// verified can safely be sent again
+type DeleteUserDataResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Decisions       uint64                 `protobuf:"varint,1,opt,name=decisions,proto3" json:"decisions,omitempty"` // Decisions the user made or received
+	DecisionEvents  uint64                 `protobuf:"varint,2,opt,name=decision_events,json=decisionEvents,proto3" json:"decision_events,omitempty"`
+	Matches         uint64                 `protobuf:"varint,3,opt,name=matches,proto3" json:"matches,omitempty"`
+	Blocks          uint64                 `protobuf:"varint,4,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	IdempotencyKeys uint64                 `protobuf:"varint,5,opt,name=idempotency_keys,json=idempotencyKeys,proto3" json:"idempotency_keys,omitempty"`
+	OutboxEvents    uint64                 `protobuf:"varint,6,opt,name=outbox_events,json=outboxEvents,proto3" json:"outbox_events,omitempty"`     // Cache writes already relayed that held the user id
+	CachedLikes     uint64                 `protobuf:"varint,7,opt,name=cached_likes,json=cachedLikes,proto3" json:"cached_likes,omitempty"`        // Likes of the user removed from other users' sorted sets
+	Remaining       uint64                 `protobuf:"varint,8,opt,name=remaining,proto3" json:"remaining,omitempty"`                               // Rows still holding the user id after the deletion
+	CacheCleared    bool                   `protobuf:"varint,9,opt,name=cache_cleared,json=cacheCleared,proto3" json:"cache_cleared,omitempty"`     // True if the user's own sorted sets are gone
+	Verified        bool                   `protobuf:"varint,10,opt,name=verified,proto3" json:"verified,omitempty"`                                // True if no rows are left and the cache was cleared
+	ExpiredPasses   uint64                 `protobuf:"varint,11,opt,name=expired_passes,json=expiredPasses,proto3" json:"expired_passes,omitempty"` // Archived passes the user made or received
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteUserDataResponse) Reset() {
+	*x = DeleteUserDataResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserDataResponse) ProtoMessage() {}
+
+func (x *DeleteUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserDataResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteUserDataResponse) GetDecisions() uint64 {
+	if x != nil {
+		return x.Decisions
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetDecisionEvents() uint64 {
+	if x != nil {
+		return x.DecisionEvents
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetMatches() uint64 {
+	if x != nil {
+		return x.Matches
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetBlocks() uint64 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetIdempotencyKeys() uint64 {
+	if x != nil {
+		return x.IdempotencyKeys
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetOutboxEvents() uint64 {
+	if x != nil {
+		return x.OutboxEvents
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetCachedLikes() uint64 {
+	if x != nil {
+		return x.CachedLikes
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetRemaining() uint64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *DeleteUserDataResponse) GetCacheCleared() bool {
+	if x != nil {
+		return x.CacheCleared
+	}
+	return false
+}
+
+func (x *DeleteUserDataResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *DeleteUserDataResponse) GetExpiredPasses() uint64 {
+	if x != nil {
+		return x.ExpiredPasses
+	}
+	return 0
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	UserId        string                       `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format        ExportUserDataRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=explore.ExportUserDataRequest_Format" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_proto_explore_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ExportUserDataRequest) GetFormat() ExportUserDataRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return ExportUserDataRequest_FORMAT_UNSPECIFIED
+}
+
+// A chunk of the export file; the file is the concatenation of every chunk in order. A
+// stream ending with an error leaves the file incomplete
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_proto_explore_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ExportUserDataResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ListLikedYouResponse_Liker struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       string                 `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLikedYouResponse_Liker) Reset() {
+	*x = ListLikedYouResponse_Liker{}
+	mi := &file_proto_explore_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLikedYouResponse_Liker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+
+func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
+func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *ListLikedYouResponse_Liker) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type PutDecisionsRequest_Decision struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RecipientUserId string                 `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PutDecisionsRequest_Decision) Reset() {
+	*x = PutDecisionsRequest_Decision{}
+	mi := &file_proto_explore_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutDecisionsRequest_Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionsRequest_Decision) ProtoMessage() {}
+
+func (x *PutDecisionsRequest_Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDecisionsRequest_Decision.ProtoReflect.Descriptor instead.
+func (*PutDecisionsRequest_Decision) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *PutDecisionsRequest_Decision) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *PutDecisionsRequest_Decision) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+type PutDecisionsResponse_Result struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MutualLikes   bool                   `protobuf:"varint,1,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"` // True if both users like each other after this decision
+	Error         *string                `protobuf:"bytes,2,opt,name=error,proto3,oneof" json:"error,omitempty"`                           // Why the decision was rejected, unset if it was recorded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutDecisionsResponse_Result) Reset() {
+	*x = PutDecisionsResponse_Result{}
+	mi := &file_proto_explore_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutDecisionsResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionsResponse_Result) ProtoMessage() {}
+
+func (x *PutDecisionsResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDecisionsResponse_Result.ProtoReflect.Descriptor instead.
+func (*PutDecisionsResponse_Result) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *PutDecisionsResponse_Result) GetMutualLikes() bool {
+	if x != nil {
+		return x.MutualLikes
+	}
+	return false
+}
+
+func (x *PutDecisionsResponse_Result) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type ListMatchesResponse_Match struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"` // When the second like landed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMatchesResponse_Match) Reset() {
+	*x = ListMatchesResponse_Match{}
+	mi := &file_proto_explore_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchesResponse_Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse_Match) ProtoMessage() {}
+
+func (x *ListMatchesResponse_Match) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse_Match.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse_Match) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{16, 0}
+}
+
+func (x *ListMatchesResponse_Match) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMatchesResponse_Match) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type GetDecisionHistoryResponse_Event struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ActorUserId     string                 `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string                 `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool                   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	Source          string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`                        // What recorded the decision, e.g. "api"
+	RequestId       string                 `protobuf:"bytes,5,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"` // Request id of the call that recorded the decision, to find its logs
+	UnixTimestamp   uint64                 `protobuf:"varint,6,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDecisionHistoryResponse_Event) Reset() {
+	*x = GetDecisionHistoryResponse_Event{}
+	mi := &file_proto_explore_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDecisionHistoryResponse_Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDecisionHistoryResponse_Event) ProtoMessage() {}
+
+func (x *GetDecisionHistoryResponse_Event) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDecisionHistoryResponse_Event.ProtoReflect.Descriptor instead.
+func (*GetDecisionHistoryResponse_Event) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{20, 0}
+}
+
+func (x *GetDecisionHistoryResponse_Event) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *GetDecisionHistoryResponse_Event) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *GetDecisionHistoryResponse_Event) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *GetDecisionHistoryResponse_Event) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GetDecisionHistoryResponse_Event) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *GetDecisionHistoryResponse_Event) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type ListBlockedResponse_Blocked struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UnixTimestamp uint64                 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"` // When the user was blocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlockedResponse_Blocked) Reset() {
+	*x = ListBlockedResponse_Blocked{}
+	mi := &file_proto_explore_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlockedResponse_Blocked) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlockedResponse_Blocked) ProtoMessage() {}
+
+func (x *ListBlockedResponse_Blocked) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_explore_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlockedResponse_Blocked.ProtoReflect.Descriptor instead.
+func (*ListBlockedResponse_Blocked) Descriptor() ([]byte, []int) {
+	return file_proto_explore_service_proto_rawDescGZIP(), []int{26, 0}
+}
+
+func (x *ListBlockedResponse_Blocked) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListBlockedResponse_Blocked) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+var File_proto_explore_service_proto protoreflect.FileDescriptor
+
+const file_proto_explore_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1bproto/explore-service.proto\x12\aexplore\"\xa6\x02\n" +
+	"\x13ListLikedYouRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01\x12$\n" +
+	"\x05order\x18\x03 \x01(\x0e2\x0e.explore.OrderR\x05order\x12\x19\n" +
+	"\x05since\x18\x04 \x01(\x04H\x01R\x05since\x88\x01\x01\x12\x19\n" +
+	"\x05until\x18\x05 \x01(\x04H\x02R\x05until\x88\x01\x01\x12 \n" +
+	"\tpage_size\x18\x06 \x01(\rH\x03R\bpageSize\x88\x01\x01B\x13\n" +
+	"\x11_pagination_tokenB\b\n" +
+	"\x06_sinceB\b\n" +
+	"\x06_untilB\f\n" +
+	"\n" +
+	"_page_size\"\xf1\x01\n" +
+	"\x14ListLikedYouResponse\x12;\n" +
+	"\x06likers\x18\x01 \x03(\v2#.explore.ListLikedYouResponse.LikerR\x06likers\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aI\n" +
+	"\x05Liker\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\tR\aactorId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"B\n" +
+	"\x14WatchLikedYouRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\"\xe4\x01\n" +
+	"\x15WatchLikedYouResponse\x127\n" +
+	"\x04type\x18\x01 \x01(\x0e2#.explore.WatchLikedYouResponse.TypeR\x04type\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\tR\aactorId\x12%\n" +
+	"\x0eunix_timestamp\x18\x03 \x01(\x04R\runixTimestamp\"P\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"TYPE_LIKED\x10\x01\x12\x10\n" +
+	"\fTYPE_UNLIKED\x10\x02\x12\x10\n" +
+	"\fTYPE_MATCHED\x10\x03\"B\n" +
+	"\x14CountLikedYouRequest\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\"-\n" +
+	"\x15CountLikedYouResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\"\xcf\x01\n" +
+	"\x12PutDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\x12,\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tH\x00R\x0eidempotencyKey\x88\x01\x01B\x12\n" +
+	"\x10_idempotency_key\"8\n" +
+	"\x13PutDecisionResponse\x12!\n" +
+	"\fmutual_likes\x18\x01 \x01(\bR\vmutualLikes\"\xdf\x01\n" +
+	"\x13PutDecisionsRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12C\n" +
+	"\tdecisions\x18\x02 \x03(\v2%.explore.PutDecisionsRequest.DecisionR\tdecisions\x1a_\n" +
+	"\bDecision\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\"\xa8\x01\n" +
+	"\x14PutDecisionsResponse\x12>\n" +
+	"\aresults\x18\x01 \x03(\v2$.explore.PutDecisionsResponse.ResultR\aresults\x1aP\n" +
+	"\x06Result\x12!\n" +
+	"\fmutual_likes\x18\x01 \x01(\bR\vmutualLikes\x12\x19\n" +
+	"\x05error\x18\x02 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"9\n" +
+	"\x13UndoDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\"\xea\x01\n" +
+	"\x14UndoDecisionResponse\x12*\n" +
+	"\x11recipient_user_id\x18\x01 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\x12=\n" +
+	"\x18restored_liked_recipient\x18\x03 \x01(\bH\x00R\x16restoredLikedRecipient\x88\x01\x01\x12!\n" +
+	"\fmutual_likes\x18\x04 \x01(\bR\vmutualLikesB\x1b\n" +
+	"\x19_restored_liked_recipient\"d\n" +
+	"\x12GetDecisionRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\"\xd5\x01\n" +
+	"\x13GetDecisionResponse\x12\x18\n" +
+	"\adecided\x18\x01 \x01(\bR\adecided\x12'\n" +
+	"\x0fliked_recipient\x18\x02 \x01(\bR\x0elikedRecipient\x12%\n" +
+	"\x0eunix_timestamp\x18\x03 \x01(\x04R\runixTimestamp\x129\n" +
+	"\x16expires_unix_timestamp\x18\x04 \x01(\x04H\x00R\x14expiresUnixTimestamp\x88\x01\x01B\x19\n" +
+	"\x17_expires_unix_timestamp\".\n" +
+	"\x12HasDecidedResponse\x12\x18\n" +
+	"\adecided\x18\x01 \x01(\bR\adecided\"r\n" +
+	"\x12ListMatchesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01B\x13\n" +
+	"\x11_pagination_token\"\xef\x01\n" +
+	"\x13ListMatchesResponse\x12<\n" +
+	"\amatches\x18\x01 \x03(\v2\".explore.ListMatchesResponse.MatchR\amatches\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aG\n" +
+	"\x05Match\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\".\n" +
+	"\x13CountMatchesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\",\n" +
+	"\x14CountMatchesResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\"\xcb\x01\n" +
+	"\x19GetDecisionHistoryRequest\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12/\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tH\x00R\x0frecipientUserId\x88\x01\x01\x12.\n" +
+	"\x10pagination_token\x18\x03 \x01(\tH\x01R\x0fpaginationToken\x88\x01\x01B\x14\n" +
+	"\x12_recipient_user_idB\x13\n" +
+	"\x11_pagination_token\"\x93\x03\n" +
+	"\x1aGetDecisionHistoryResponse\x12A\n" +
+	"\x06events\x18\x01 \x03(\v2).explore.GetDecisionHistoryResponse.EventR\x06events\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1a\xde\x01\n" +
+	"\x05Event\x12\"\n" +
+	"\ractor_user_id\x18\x01 \x01(\tR\vactorUserId\x12*\n" +
+	"\x11recipient_user_id\x18\x02 \x01(\tR\x0frecipientUserId\x12'\n" +
+	"\x0fliked_recipient\x18\x03 \x01(\bR\x0elikedRecipient\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x05 \x01(\tR\trequestId\x12%\n" +
+	"\x0eunix_timestamp\x18\x06 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"{\n" +
+	"\x10BlockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\tR\rblockedUserId\x12\x1b\n" +
+	"\x06reason\x18\x03 \x01(\tH\x00R\x06reason\x88\x01\x01B\t\n" +
+	"\a_reason\"\x13\n" +
+	"\x11BlockUserResponse\"U\n" +
+	"\x12UnblockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0fblocked_user_id\x18\x02 \x01(\tR\rblockedUserId\"\x15\n" +
+	"\x13UnblockUserResponse\"r\n" +
+	"\x12ListBlockedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
+	"\x10pagination_token\x18\x02 \x01(\tH\x00R\x0fpaginationToken\x88\x01\x01B\x13\n" +
+	"\x11_pagination_token\"\xf3\x01\n" +
+	"\x13ListBlockedResponse\x12>\n" +
+	"\ablocked\x18\x01 \x03(\v2$.explore.ListBlockedResponse.BlockedR\ablocked\x127\n" +
+	"\x15next_pagination_token\x18\x02 \x01(\tH\x00R\x13nextPaginationToken\x88\x01\x01\x1aI\n" +
+	"\aBlocked\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x0eunix_timestamp\x18\x02 \x01(\x04R\runixTimestampB\x18\n" +
+	"\x16_next_pagination_token\"0\n" +
+	"\x15DeleteUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x8a\x03\n" +
+	"\x16DeleteUserDataResponse\x12\x1c\n" +
+	"\tdecisions\x18\x01 \x01(\x04R\tdecisions\x12'\n" +
+	"\x0fdecision_events\x18\x02 \x01(\x04R\x0edecisionEvents\x12\x18\n" +
+	"\amatches\x18\x03 \x01(\x04R\amatches\x12\x16\n" +
+	"\x06blocks\x18\x04 \x01(\x04R\x06blocks\x12)\n" +
+	"\x10idempotency_keys\x18\x05 \x01(\x04R\x0fidempotencyKeys\x12#\n" +
+	"\routbox_events\x18\x06 \x01(\x04R\foutboxEvents\x12!\n" +
+	"\fcached_likes\x18\a \x01(\x04R\vcachedLikes\x12\x1c\n" +
+	"\tremaining\x18\b \x01(\x04R\tremaining\x12#\n" +
+	"\rcache_cleared\x18\t \x01(\bR\fcacheCleared\x12\x1a\n" +
+	"\bverified\x18\n" +
+	" \x01(\bR\bverified\x12%\n" +
+	"\x0eexpired_passes\x18\v \x01(\x04R\rexpiredPasses\"\xb8\x01\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12=\n" +
+	"\x06format\x18\x02 \x01(\x0e2%.explore.ExportUserDataRequest.FormatR\x06format\"G\n" +
+	"\x06Format\x12\x16\n" +
+	"\x12FORMAT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11FORMAT_JSON_LINES\x10\x01\x12\x0e\n" +
+	"\n" +
+	"FORMAT_CSV\x10\x02\",\n" +
+	"\x16ExportUserDataResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data*N\n" +
+	"\x05Order\x12\x15\n" +
+	"\x11ORDER_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12ORDER_OLDEST_FIRST\x10\x01\x12\x16\n" +
+	"\x12ORDER_NEWEST_FIRST\x10\x022\xbb\n" +
+	"\n" +
+	"\x0eExploreService\x12K\n" +
+	"\fListLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12N\n" +
+	"\x0fListNewLikedYou\x12\x1c.explore.ListLikedYouRequest\x1a\x1d.explore.ListLikedYouResponse\x12P\n" +
+	"\rWatchLikedYou\x12\x1d.explore.WatchLikedYouRequest\x1a\x1e.explore.WatchLikedYouResponse0\x01\x12N\n" +
+	"\rCountLikedYou\x12\x1d.explore.CountLikedYouRequest\x1a\x1e.explore.CountLikedYouResponse\x12H\n" +
+	"\vPutDecision\x12\x1b.explore.PutDecisionRequest\x1a\x1c.explore.PutDecisionResponse\x12K\n" +
+	"\fPutDecisions\x12\x1c.explore.PutDecisionsRequest\x1a\x1d.explore.PutDecisionsResponse\x12K\n" +
+	"\fUndoDecision\x12\x1c.explore.UndoDecisionRequest\x1a\x1d.explore.UndoDecisionResponse\x12H\n" +
+	"\vGetDecision\x12\x1b.explore.GetDecisionRequest\x1a\x1c.explore.GetDecisionResponse\x12F\n" +
+	"\n" +
+	"HasDecided\x12\x1b.explore.GetDecisionRequest\x1a\x1b.explore.HasDecidedResponse\x12H\n" +
+	"\vListMatches\x12\x1b.explore.ListMatchesRequest\x1a\x1c.explore.ListMatchesResponse\x12K\n" +
+	"\fCountMatches\x12\x1c.explore.CountMatchesRequest\x1a\x1d.explore.CountMatchesResponse\x12]\n" +
+	"\x12GetDecisionHistory\x12\".explore.GetDecisionHistoryRequest\x1a#.explore.GetDecisionHistoryResponse\x12B\n" +
+	"\tBlockUser\x12\x19.explore.BlockUserRequest\x1a\x1a.explore.BlockUserResponse\x12H\n" +
+	"\vUnblockUser\x12\x1b.explore.UnblockUserRequest\x1a\x1c.explore.UnblockUserResponse\x12H\n" +
+	"\vListBlocked\x12\x1b.explore.ListBlockedRequest\x1a\x1c.explore.ListBlockedResponse\x12Q\n" +
+	"\x0eDeleteUserData\x12\x1e.explore.DeleteUserDataRequest\x1a\x1f.explore.DeleteUserDataResponse\x12S\n" +
+	"\x0eExportUserData\x12\x1e.explore.ExportUserDataRequest\x1a\x1f.explore.ExportUserDataResponse0\x01B\x0fZ\rmuzzapp/protob\x06proto3"
 
 var (
 	file_proto_explore_service_proto_rawDescOnce sync.Once
@@ -413,31 +2385,99 @@ func file_proto_explore_service_proto_rawDescGZIP() []byte {
 	return file_proto_explore_service_proto_rawDescData
 }
 
-var file_proto_explore_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_explore_service_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_explore_service_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_proto_explore_service_proto_goTypes = []any{
-	(*ListLikedYouRequest)(nil),        // 0: explore.ListLikedYouRequest
-	(*ListLikedYouResponse)(nil),       // 1: explore.ListLikedYouResponse
-	(*CountLikedYouRequest)(nil),       // 2: explore.CountLikedYouRequest
-	(*CountLikedYouResponse)(nil),      // 3: explore.CountLikedYouResponse
-	(*PutDecisionRequest)(nil),         // 4: explore.PutDecisionRequest
-	(*PutDecisionResponse)(nil),        // 5: explore.PutDecisionResponse
-	(*ListLikedYouResponse_Liker)(nil), // 6: explore.ListLikedYouResponse.Liker
+	(Order)(0),                               // 0: explore.Order
+	(WatchLikedYouResponse_Type)(0),          // 1: explore.WatchLikedYouResponse.Type
+	(ExportUserDataRequest_Format)(0),        // 2: explore.ExportUserDataRequest.Format
+	(*ListLikedYouRequest)(nil),              // 3: explore.ListLikedYouRequest
+	(*ListLikedYouResponse)(nil),             // 4: explore.ListLikedYouResponse
+	(*WatchLikedYouRequest)(nil),             // 5: explore.WatchLikedYouRequest
+	(*WatchLikedYouResponse)(nil),            // 6: explore.WatchLikedYouResponse
+	(*CountLikedYouRequest)(nil),             // 7: explore.CountLikedYouRequest
+	(*CountLikedYouResponse)(nil),            // 8: explore.CountLikedYouResponse
+	(*PutDecisionRequest)(nil),               // 9: explore.PutDecisionRequest
+	(*PutDecisionResponse)(nil),              // 10: explore.PutDecisionResponse
+	(*PutDecisionsRequest)(nil),              // 11: explore.PutDecisionsRequest
+	(*PutDecisionsResponse)(nil),             // 12: explore.PutDecisionsResponse
+	(*UndoDecisionRequest)(nil),              // 13: explore.UndoDecisionRequest
+	(*UndoDecisionResponse)(nil),             // 14: explore.UndoDecisionResponse
+	(*GetDecisionRequest)(nil),               // 15: explore.GetDecisionRequest
+	(*GetDecisionResponse)(nil),              // 16: explore.GetDecisionResponse
+	(*HasDecidedResponse)(nil),               // 17: explore.HasDecidedResponse
+	(*ListMatchesRequest)(nil),               // 18: explore.ListMatchesRequest
+	(*ListMatchesResponse)(nil),              // 19: explore.ListMatchesResponse
+	(*CountMatchesRequest)(nil),              // 20: explore.CountMatchesRequest
+	(*CountMatchesResponse)(nil),             // 21: explore.CountMatchesResponse
+	(*GetDecisionHistoryRequest)(nil),        // 22: explore.GetDecisionHistoryRequest
+	(*GetDecisionHistoryResponse)(nil),       // 23: explore.GetDecisionHistoryResponse
+	(*BlockUserRequest)(nil),                 // 24: explore.BlockUserRequest
+	(*BlockUserResponse)(nil),                // 25: explore.BlockUserResponse
+	(*UnblockUserRequest)(nil),               // 26: explore.UnblockUserRequest
+	(*UnblockUserResponse)(nil),              // 27: explore.UnblockUserResponse
+	(*ListBlockedRequest)(nil),               // 28: explore.ListBlockedRequest
+	(*ListBlockedResponse)(nil),              // 29: explore.ListBlockedResponse
+	(*DeleteUserDataRequest)(nil),            // 30: explore.DeleteUserDataRequest
+	(*DeleteUserDataResponse)(nil),           // 31: explore.DeleteUserDataResponse
+	(*ExportUserDataRequest)(nil),            // 32: explore.ExportUserDataRequest
+	(*ExportUserDataResponse)(nil),           // 33: explore.ExportUserDataResponse
+	(*ListLikedYouResponse_Liker)(nil),       // 34: explore.ListLikedYouResponse.Liker
+	(*PutDecisionsRequest_Decision)(nil),     // 35: explore.PutDecisionsRequest.Decision
+	(*PutDecisionsResponse_Result)(nil),      // 36: explore.PutDecisionsResponse.Result
+	(*ListMatchesResponse_Match)(nil),        // 37: explore.ListMatchesResponse.Match
+	(*GetDecisionHistoryResponse_Event)(nil), // 38: explore.GetDecisionHistoryResponse.Event
+	(*ListBlockedResponse_Blocked)(nil),      // 39: explore.ListBlockedResponse.Blocked
 }
 var file_proto_explore_service_proto_depIdxs = []int32{
-	6, // 0: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
-	0, // 1: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
-	0, // 2: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
-	2, // 3: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
-	4, // 4: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
-	1, // 5: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
-	1, // 6: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
-	3, // 7: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
-	5, // 8: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: explore.ListLikedYouRequest.order:type_name -> explore.Order
+	34, // 1: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
+	1,  // 2: explore.WatchLikedYouResponse.type:type_name -> explore.WatchLikedYouResponse.Type
+	35, // 3: explore.PutDecisionsRequest.decisions:type_name -> explore.PutDecisionsRequest.Decision
+	36, // 4: explore.PutDecisionsResponse.results:type_name -> explore.PutDecisionsResponse.Result
+	37, // 5: explore.ListMatchesResponse.matches:type_name -> explore.ListMatchesResponse.Match
+	38, // 6: explore.GetDecisionHistoryResponse.events:type_name -> explore.GetDecisionHistoryResponse.Event
+	39, // 7: explore.ListBlockedResponse.blocked:type_name -> explore.ListBlockedResponse.Blocked
+	2,  // 8: explore.ExportUserDataRequest.format:type_name -> explore.ExportUserDataRequest.Format
+	3,  // 9: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
+	3,  // 10: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
+	5,  // 11: explore.ExploreService.WatchLikedYou:input_type -> explore.WatchLikedYouRequest
+	7,  // 12: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
+	9,  // 13: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
+	11, // 14: explore.ExploreService.PutDecisions:input_type -> explore.PutDecisionsRequest
+	13, // 15: explore.ExploreService.UndoDecision:input_type -> explore.UndoDecisionRequest
+	15, // 16: explore.ExploreService.GetDecision:input_type -> explore.GetDecisionRequest
+	15, // 17: explore.ExploreService.HasDecided:input_type -> explore.GetDecisionRequest
+	18, // 18: explore.ExploreService.ListMatches:input_type -> explore.ListMatchesRequest
+	20, // 19: explore.ExploreService.CountMatches:input_type -> explore.CountMatchesRequest
+	22, // 20: explore.ExploreService.GetDecisionHistory:input_type -> explore.GetDecisionHistoryRequest
+	24, // 21: explore.ExploreService.BlockUser:input_type -> explore.BlockUserRequest
+	26, // 22: explore.ExploreService.UnblockUser:input_type -> explore.UnblockUserRequest
+	28, // 23: explore.ExploreService.ListBlocked:input_type -> explore.ListBlockedRequest
+	30, // 24: explore.ExploreService.DeleteUserData:input_type -> explore.DeleteUserDataRequest
+	32, // 25: explore.ExploreService.ExportUserData:input_type -> explore.ExportUserDataRequest
+	4,  // 26: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
+	4,  // 27: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
+	6,  // 28: explore.ExploreService.WatchLikedYou:output_type -> explore.WatchLikedYouResponse
+	8,  // 29: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
+	10, // 30: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
+	12, // 31: explore.ExploreService.PutDecisions:output_type -> explore.PutDecisionsResponse
+	14, // 32: explore.ExploreService.UndoDecision:output_type -> explore.UndoDecisionResponse
+	16, // 33: explore.ExploreService.GetDecision:output_type -> explore.GetDecisionResponse
+	17, // 34: explore.ExploreService.HasDecided:output_type -> explore.HasDecidedResponse
+	19, // 35: explore.ExploreService.ListMatches:output_type -> explore.ListMatchesResponse
+	21, // 36: explore.ExploreService.CountMatches:output_type -> explore.CountMatchesResponse
+	23, // 37: explore.ExploreService.GetDecisionHistory:output_type -> explore.GetDecisionHistoryResponse
+	25, // 38: explore.ExploreService.BlockUser:output_type -> explore.BlockUserResponse
+	27, // 39: explore.ExploreService.UnblockUser:output_type -> explore.UnblockUserResponse
+	29, // 40: explore.ExploreService.ListBlocked:output_type -> explore.ListBlockedResponse
+	31, // 41: explore.ExploreService.DeleteUserData:output_type -> explore.DeleteUserDataResponse
+	33, // 42: explore.ExploreService.ExportUserData:output_type -> explore.ExportUserDataResponse
+	26, // [26:43] is the sub-list for method output_type
+	9,  // [9:26] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_proto_explore_service_proto_init() }
@@ -447,18 +2487,30 @@ func file_proto_explore_service_proto_init() {
 	}
 	file_proto_explore_service_proto_msgTypes[0].OneofWrappers = []any{}
 	file_proto_explore_service_proto_msgTypes[1].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[11].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[13].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[15].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[16].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[19].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[20].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[21].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[25].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[26].OneofWrappers = []any{}
+	file_proto_explore_service_proto_msgTypes[33].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_explore_service_proto_rawDesc), len(file_proto_explore_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      3,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_explore_service_proto_goTypes,
 		DependencyIndexes: file_proto_explore_service_proto_depIdxs,
+		EnumInfos:         file_proto_explore_service_proto_enumTypes,
 		MessageInfos:      file_proto_explore_service_proto_msgTypes,
 	}.Build()
 	File_proto_explore_service_proto = out.File
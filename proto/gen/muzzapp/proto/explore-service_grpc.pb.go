@@ -19,10 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ExploreService_ListLikedYou_FullMethodName    = "/explore.ExploreService/ListLikedYou"
-	ExploreService_ListNewLikedYou_FullMethodName = "/explore.ExploreService/ListNewLikedYou"
-	ExploreService_CountLikedYou_FullMethodName   = "/explore.ExploreService/CountLikedYou"
-	ExploreService_PutDecision_FullMethodName     = "/explore.ExploreService/PutDecision"
+	ExploreService_ListLikedYou_FullMethodName       = "/explore.ExploreService/ListLikedYou"
+	ExploreService_ListNewLikedYou_FullMethodName    = "/explore.ExploreService/ListNewLikedYou"
+	ExploreService_WatchLikedYou_FullMethodName      = "/explore.ExploreService/WatchLikedYou"
+	ExploreService_CountLikedYou_FullMethodName      = "/explore.ExploreService/CountLikedYou"
+	ExploreService_PutDecision_FullMethodName        = "/explore.ExploreService/PutDecision"
+	ExploreService_PutDecisions_FullMethodName       = "/explore.ExploreService/PutDecisions"
+	ExploreService_UndoDecision_FullMethodName       = "/explore.ExploreService/UndoDecision"
+	ExploreService_GetDecision_FullMethodName        = "/explore.ExploreService/GetDecision"
+	ExploreService_HasDecided_FullMethodName         = "/explore.ExploreService/HasDecided"
+	ExploreService_ListMatches_FullMethodName        = "/explore.ExploreService/ListMatches"
+	ExploreService_CountMatches_FullMethodName       = "/explore.ExploreService/CountMatches"
+	ExploreService_GetDecisionHistory_FullMethodName = "/explore.ExploreService/GetDecisionHistory"
+	ExploreService_BlockUser_FullMethodName          = "/explore.ExploreService/BlockUser"
+	ExploreService_UnblockUser_FullMethodName        = "/explore.ExploreService/UnblockUser"
+	ExploreService_ListBlocked_FullMethodName        = "/explore.ExploreService/ListBlocked"
+	ExploreService_DeleteUserData_FullMethodName     = "/explore.ExploreService/DeleteUserData"
+	ExploreService_ExportUserData_FullMethodName     = "/explore.ExploreService/ExportUserData"
 )
 
 // ExploreServiceClient is the client API for ExploreService service.
@@ -31,8 +44,21 @@ const (
 type ExploreServiceClient interface {
 	ListLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error)
 	ListNewLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error)
+	WatchLikedYou(ctx context.Context, in *WatchLikedYouRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchLikedYouResponse], error)
 	CountLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error)
 	PutDecision(ctx context.Context, in *PutDecisionRequest, opts ...grpc.CallOption) (*PutDecisionResponse, error)
+	PutDecisions(ctx context.Context, in *PutDecisionsRequest, opts ...grpc.CallOption) (*PutDecisionsResponse, error)
+	UndoDecision(ctx context.Context, in *UndoDecisionRequest, opts ...grpc.CallOption) (*UndoDecisionResponse, error)
+	GetDecision(ctx context.Context, in *GetDecisionRequest, opts ...grpc.CallOption) (*GetDecisionResponse, error)
+	HasDecided(ctx context.Context, in *GetDecisionRequest, opts ...grpc.CallOption) (*HasDecidedResponse, error)
+	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error)
+	CountMatches(ctx context.Context, in *CountMatchesRequest, opts ...grpc.CallOption) (*CountMatchesResponse, error)
+	GetDecisionHistory(ctx context.Context, in *GetDecisionHistoryRequest, opts ...grpc.CallOption) (*GetDecisionHistoryResponse, error)
+	BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error)
+	UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error)
+	ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*ListBlockedResponse, error)
+	DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error)
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUserDataResponse], error)
 }
 
 type exploreServiceClient struct {
@@ -63,6 +89,25 @@ func (c *exploreServiceClient) ListNewLikedYou(ctx context.Context, in *ListLike
 	return out, nil
 }
 
+func (c *exploreServiceClient) WatchLikedYou(ctx context.Context, in *WatchLikedYouRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchLikedYouResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExploreService_ServiceDesc.Streams[0], ExploreService_WatchLikedYou_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchLikedYouRequest, WatchLikedYouResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_WatchLikedYouClient = grpc.ServerStreamingClient[WatchLikedYouResponse]
+
 func (c *exploreServiceClient) CountLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CountLikedYouResponse)
@@ -83,14 +128,156 @@ func (c *exploreServiceClient) PutDecision(ctx context.Context, in *PutDecisionR
 	return out, nil
 }
 
+func (c *exploreServiceClient) PutDecisions(ctx context.Context, in *PutDecisionsRequest, opts ...grpc.CallOption) (*PutDecisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutDecisionsResponse)
+	err := c.cc.Invoke(ctx, ExploreService_PutDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) UndoDecision(ctx context.Context, in *UndoDecisionRequest, opts ...grpc.CallOption) (*UndoDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UndoDecisionResponse)
+	err := c.cc.Invoke(ctx, ExploreService_UndoDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) GetDecision(ctx context.Context, in *GetDecisionRequest, opts ...grpc.CallOption) (*GetDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDecisionResponse)
+	err := c.cc.Invoke(ctx, ExploreService_GetDecision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) HasDecided(ctx context.Context, in *GetDecisionRequest, opts ...grpc.CallOption) (*HasDecidedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HasDecidedResponse)
+	err := c.cc.Invoke(ctx, ExploreService_HasDecided_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMatchesResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) CountMatches(ctx context.Context, in *CountMatchesRequest, opts ...grpc.CallOption) (*CountMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountMatchesResponse)
+	err := c.cc.Invoke(ctx, ExploreService_CountMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) GetDecisionHistory(ctx context.Context, in *GetDecisionHistoryRequest, opts ...grpc.CallOption) (*GetDecisionHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDecisionHistoryResponse)
+	err := c.cc.Invoke(ctx, ExploreService_GetDecisionHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_BlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnblockUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_UnblockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListBlocked(ctx context.Context, in *ListBlockedRequest, opts ...grpc.CallOption) (*ListBlockedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBlockedResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListBlocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) DeleteUserData(ctx context.Context, in *DeleteUserDataRequest, opts ...grpc.CallOption) (*DeleteUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserDataResponse)
+	err := c.cc.Invoke(ctx, ExploreService_DeleteUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUserDataResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExploreService_ServiceDesc.Streams[1], ExploreService_ExportUserData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportUserDataRequest, ExportUserDataResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_ExportUserDataClient = grpc.ServerStreamingClient[ExportUserDataResponse]
+
 // ExploreServiceServer is the server API for ExploreService service.
 // All implementations must embed UnimplementedExploreServiceServer
 // for forward compatibility.
 type ExploreServiceServer interface {
 	ListLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
 	ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
+	WatchLikedYou(*WatchLikedYouRequest, grpc.ServerStreamingServer[WatchLikedYouResponse]) error
 	CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error)
 	PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error)
+	PutDecisions(context.Context, *PutDecisionsRequest) (*PutDecisionsResponse, error)
+	UndoDecision(context.Context, *UndoDecisionRequest) (*UndoDecisionResponse, error)
+	GetDecision(context.Context, *GetDecisionRequest) (*GetDecisionResponse, error)
+	HasDecided(context.Context, *GetDecisionRequest) (*HasDecidedResponse, error)
+	ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error)
+	CountMatches(context.Context, *CountMatchesRequest) (*CountMatchesResponse, error)
+	GetDecisionHistory(context.Context, *GetDecisionHistoryRequest) (*GetDecisionHistoryResponse, error)
+	BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error)
+	UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error)
+	ListBlocked(context.Context, *ListBlockedRequest) (*ListBlockedResponse, error)
+	DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error)
+	ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportUserDataResponse]) error
 	mustEmbedUnimplementedExploreServiceServer()
 }
 
@@ -107,12 +294,51 @@ func (UnimplementedExploreServiceServer) ListLikedYou(context.Context, *ListLike
 func (UnimplementedExploreServiceServer) ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListNewLikedYou not implemented")
 }
+func (UnimplementedExploreServiceServer) WatchLikedYou(*WatchLikedYouRequest, grpc.ServerStreamingServer[WatchLikedYouResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLikedYou not implemented")
+}
 func (UnimplementedExploreServiceServer) CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CountLikedYou not implemented")
 }
 func (UnimplementedExploreServiceServer) PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutDecision not implemented")
 }
+func (UnimplementedExploreServiceServer) PutDecisions(context.Context, *PutDecisionsRequest) (*PutDecisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutDecisions not implemented")
+}
+func (UnimplementedExploreServiceServer) UndoDecision(context.Context, *UndoDecisionRequest) (*UndoDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndoDecision not implemented")
+}
+func (UnimplementedExploreServiceServer) GetDecision(context.Context, *GetDecisionRequest) (*GetDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDecision not implemented")
+}
+func (UnimplementedExploreServiceServer) HasDecided(context.Context, *GetDecisionRequest) (*HasDecidedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HasDecided not implemented")
+}
+func (UnimplementedExploreServiceServer) ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (UnimplementedExploreServiceServer) CountMatches(context.Context, *CountMatchesRequest) (*CountMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountMatches not implemented")
+}
+func (UnimplementedExploreServiceServer) GetDecisionHistory(context.Context, *GetDecisionHistoryRequest) (*GetDecisionHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDecisionHistory not implemented")
+}
+func (UnimplementedExploreServiceServer) BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedExploreServiceServer) UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedExploreServiceServer) ListBlocked(context.Context, *ListBlockedRequest) (*ListBlockedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBlocked not implemented")
+}
+func (UnimplementedExploreServiceServer) DeleteUserData(context.Context, *DeleteUserDataRequest) (*DeleteUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserData not implemented")
+}
+func (UnimplementedExploreServiceServer) ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportUserDataResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
 func (UnimplementedExploreServiceServer) mustEmbedUnimplementedExploreServiceServer() {}
 func (UnimplementedExploreServiceServer) testEmbeddedByValue()                        {}
 
@@ -170,6 +396,17 @@ func _ExploreService_ListNewLikedYou_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExploreService_WatchLikedYou_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchLikedYouRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExploreServiceServer).WatchLikedYou(m, &grpc.GenericServerStream[WatchLikedYouRequest, WatchLikedYouResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_WatchLikedYouServer = grpc.ServerStreamingServer[WatchLikedYouResponse]
+
 func _ExploreService_CountLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CountLikedYouRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +443,215 @@ func _ExploreService_PutDecision_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExploreService_PutDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).PutDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_PutDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).PutDecisions(ctx, req.(*PutDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_UndoDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndoDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).UndoDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_UndoDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).UndoDecision(ctx, req.(*UndoDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_GetDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).GetDecision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_GetDecision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).GetDecision(ctx, req.(*GetDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_HasDecided_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).HasDecided(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_HasDecided_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).HasDecided(ctx, req.(*GetDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListMatches(ctx, req.(*ListMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_CountMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).CountMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_CountMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).CountMatches(ctx, req.(*CountMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_GetDecisionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDecisionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).GetDecisionHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_GetDecisionHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).GetDecisionHistory(ctx, req.(*GetDecisionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).BlockUser(ctx, req.(*BlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_UnblockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).UnblockUser(ctx, req.(*UnblockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBlockedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListBlocked_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListBlocked(ctx, req.(*ListBlockedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_DeleteUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).DeleteUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_DeleteUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).DeleteUserData(ctx, req.(*DeleteUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExploreServiceServer).ExportUserData(m, &grpc.GenericServerStream[ExportUserDataRequest, ExportUserDataResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ExploreService_ExportUserDataServer = grpc.ServerStreamingServer[ExportUserDataResponse]
+
 // ExploreService_ServiceDesc is the grpc.ServiceDesc for ExploreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,7 +675,62 @@ var ExploreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PutDecision",
 			Handler:    _ExploreService_PutDecision_Handler,
 		},
+		{
+			MethodName: "PutDecisions",
+			Handler:    _ExploreService_PutDecisions_Handler,
+		},
+		{
+			MethodName: "UndoDecision",
+			Handler:    _ExploreService_UndoDecision_Handler,
+		},
+		{
+			MethodName: "GetDecision",
+			Handler:    _ExploreService_GetDecision_Handler,
+		},
+		{
+			MethodName: "HasDecided",
+			Handler:    _ExploreService_HasDecided_Handler,
+		},
+		{
+			MethodName: "ListMatches",
+			Handler:    _ExploreService_ListMatches_Handler,
+		},
+		{
+			MethodName: "CountMatches",
+			Handler:    _ExploreService_CountMatches_Handler,
+		},
+		{
+			MethodName: "GetDecisionHistory",
+			Handler:    _ExploreService_GetDecisionHistory_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _ExploreService_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _ExploreService_UnblockUser_Handler,
+		},
+		{
+			MethodName: "ListBlocked",
+			Handler:    _ExploreService_ListBlocked_Handler,
+		},
+		{
+			MethodName: "DeleteUserData",
+			Handler:    _ExploreService_DeleteUserData_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLikedYou",
+			Handler:       _ExploreService_WatchLikedYou_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _ExploreService_ExportUserData_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/explore-service.proto",
 }